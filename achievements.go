@@ -0,0 +1,131 @@
+package steam
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const apiGetAchievementsProgress = "https://api.steampowered.com/IPlayerService/GetAchievementsProgress/v1/?"
+
+type AchievementsProgress struct {
+	AppID       uint32  `json:"appid"`
+	Unlocked    uint32  `json:"unlocked"`
+	Total       uint32  `json:"total"`
+	Percentage  float64 `json:"percentage"`
+	AllUnlocked bool    `json:"all_unlocked"`
+	CacheTime   int64   `json:"cache_time"`
+}
+
+// ShowcaseAchievement is an achievement picked for the showcase, Name is the
+// tooltip of the icon.
+type ShowcaseAchievement struct {
+	Name    string
+	IconURL string
+}
+
+// AchievementShowcase is the achievement showcase of a profile, the stats are as
+// shown (e.g. "1,234" achievements, "42%" completion) and empty if hidden.
+type AchievementShowcase struct {
+	Achievements   []*ShowcaseAchievement
+	Total          string
+	PerfectGames   string
+	CompletionRate string
+}
+
+// GetAchievementsProgress returns the achievements unlocked by @sid in each of @appIDs,
+// the games without achievements are left out.
+func (session *Session) GetAchievementsProgress(sid SteamID, appIDs []uint32) ([]*AchievementsProgress, error) {
+	params := url.Values{
+		"key":      {session.apiKey},
+		"steamid":  {sid.ToString()},
+		"language": {session.language},
+	}
+	for i, appID := range appIDs {
+		params.Set(fmt.Sprintf("appids[%d]", i), strconv.FormatUint(uint64(appID), 10))
+	}
+
+	type Progress struct {
+		Progress []*AchievementsProgress `json:"achievement_progress"`
+	}
+
+	resp, err := session.client.Get(apiGetAchievementsProgress + params.Encode())
+
+	progress := &Progress{}
+	if err = session.decodeServiceResponse(resp, err, progress); err != nil {
+		return nil, err
+	}
+
+	return progress.Progress, nil
+}
+
+// CountCompletedGames returns in how many of @appIDs @sid has every achievement.
+func (session *Session) CountCompletedGames(sid SteamID, appIDs []uint32) (int, error) {
+	progress, err := session.GetAchievementsProgress(sid, appIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for _, game := range progress {
+		if game.AllUnlocked {
+			completed++
+		}
+	}
+
+	return completed, nil
+}
+
+// GetAchievementShowcase reads the achievement showcase from the profile page of
+// @sid, nil is returned if the profile doesn't have one.
+func (session *Session) GetAchievementShowcase(sid SteamID) (*AchievementShowcase, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/profiles/" + sid.ToString() + "/?l=english")
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	box := doc.Find(".achievement_showcase").First()
+	if box.Length() == 0 {
+		return nil, nil
+	}
+
+	showcase := &AchievementShowcase{Achievements: []*ShowcaseAchievement{}}
+	box.Find(".showcase_achievement").Each(func(i int, s *goquery.Selection) {
+		img := s.Find("img")
+		showcase.Achievements = append(showcase.Achievements, &ShowcaseAchievement{
+			Name:    strings.TrimSpace(s.AttrOr("data-tooltip-text", img.AttrOr("alt", ""))),
+			IconURL: img.AttrOr("src", ""),
+		})
+	})
+
+	box.Find(".showcase_stat").Each(func(i int, s *goquery.Selection) {
+		value := strings.TrimSpace(s.Find(".value").Text())
+		switch label := strings.TrimSpace(s.Find(".label").Text()); {
+		case strings.HasPrefix(label, "Perfect"):
+			showcase.PerfectGames = value
+		case strings.Contains(label, "Completion"):
+			showcase.CompletionRate = value
+		case strings.HasPrefix(label, "Achievements"):
+			showcase.Total = value
+		}
+	})
+
+	return showcase, nil
+}
@@ -0,0 +1,87 @@
+package steam
+
+import "sync"
+
+// InventoryOwner is an inventory to aggregate, fetched with Session (which may be
+// shared by several owners for public inventories).  A zero SteamID is the
+// session's own account.
+type InventoryOwner struct {
+	Session *Session
+	SteamID SteamID
+}
+
+type OwnedInventoryItem struct {
+	Owner SteamID
+	Item  *InventoryItem
+}
+
+// AggregatedInventory has the inventories of every owner that could be fetched
+// in Inventories, and the errors of the others in Errors.
+type AggregatedInventory struct {
+	Inventories map[SteamID][]InventoryItem
+	Errors      map[SteamID]error
+}
+
+// ByMarketHashName groups every item by market hash name along with its owner,
+// items without a description are left out.
+func (aggregated *AggregatedInventory) ByMarketHashName() map[string][]*OwnedInventoryItem {
+	names := make(map[string][]*OwnedInventoryItem)
+	for owner, items := range aggregated.Inventories {
+		for i := range items {
+			if desc := items[i].Desc; desc != nil {
+				names[desc.MarketHashName] = append(names[desc.MarketHashName], &OwnedInventoryItem{
+					Owner: owner,
+					Item:  &items[i],
+				})
+			}
+		}
+	}
+
+	return names
+}
+
+// AggregateInventories fetches the inventories of @owners with at most @parallel
+// (0 for no limit) of them at once, on top of the limits of each session.
+func AggregateInventories(owners []*InventoryOwner, appID, contextID uint64, parallel int) *AggregatedInventory {
+	aggregated := &AggregatedInventory{
+		Inventories: make(map[SteamID][]InventoryItem),
+		Errors:      make(map[SteamID]error),
+	}
+
+	var slots chan struct{}
+	if parallel > 0 {
+		slots = make(chan struct{}, parallel)
+	}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	for _, owner := range owners {
+		sid := owner.SteamID
+		if sid == 0 {
+			sid = owner.Session.GetSteamID()
+		}
+
+		wg.Add(1)
+		go func(session *Session, sid SteamID) {
+			defer wg.Done()
+
+			if slots != nil {
+				slots <- struct{}{}
+				defer func() { <-slots }()
+			}
+
+			items, err := session.GetInventory(sid, appID, contextID, false)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				aggregated.Errors[sid] = err
+			} else {
+				aggregated.Inventories[sid] = items
+			}
+		}(owner.Session, sid)
+	}
+
+	wg.Wait()
+	return aggregated
+}
@@ -0,0 +1,117 @@
+package steam
+
+import "sort"
+
+// FXRateSource converts between currencies, codes are ISO 4217 (see CurrencyCodes).
+type FXRateSource interface {
+	// Rate returns how much of @to one unit of @from is worth.
+	Rate(from, to string) (float64, error)
+}
+
+type ArbitrageQuote struct {
+	CurrencyID string
+	Overview   *MarketItemPriceOverview
+	// Lowest is the lowest price in cents of CurrencyID, Converted is the
+	// same in cents of the base currency.
+	Lowest    uint64
+	Converted float64
+}
+
+// ArbitrageSpread is buying at Buy and selling at Sell, Spread is the
+// difference relative to the buy price (0.1 = 10%), fees not included.
+type ArbitrageSpread struct {
+	Buy    *ArbitrageQuote
+	Sell   *ArbitrageQuote
+	Spread float64
+}
+
+// GetArbitrageQuotes fetches the price overview of the item in every currency of
+// @currencyIDs and converts the lowest prices to @baseCurrency (an ISO code).
+// Currencies without a lowest price are skipped.
+func (session *Session) GetArbitrageQuotes(
+	appID uint64,
+	country, marketHashName string,
+	currencyIDs []string,
+	baseCurrency string,
+	fx FXRateSource,
+) ([]*ArbitrageQuote, error) {
+	quotes := []*ArbitrageQuote{}
+	for _, currencyID := range currencyIDs {
+		code, ok := CurrencyCodes[currencyID]
+		if !ok {
+			continue
+		}
+
+		overview, err := session.GetMarketItemPriceOverview(appID, country, currencyID, marketHashName)
+		if err != nil {
+			return nil, err
+		}
+
+		lowest := overview.ParsedLowest
+		if !overview.Success || lowest == 0 {
+			continue
+		}
+
+		rate, err := fx.Rate(code, baseCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		quotes = append(quotes, &ArbitrageQuote{
+			CurrencyID: currencyID,
+			Overview:   overview,
+			Lowest:     lowest,
+			Converted:  float64(lowest) * rate,
+		})
+	}
+
+	return quotes, nil
+}
+
+// FindArbitrageSpreads returns every pair of @quotes with a spread of at least
+// @threshold, largest spread first.
+func FindArbitrageSpreads(quotes []*ArbitrageQuote, threshold float64) []*ArbitrageSpread {
+	spreads := []*ArbitrageSpread{}
+	for _, buy := range quotes {
+		if buy.Converted <= 0 {
+			continue
+		}
+
+		for _, sell := range quotes {
+			if buy == sell {
+				continue
+			}
+
+			spread := (sell.Converted - buy.Converted) / buy.Converted
+			if spread >= threshold {
+				spreads = append(spreads, &ArbitrageSpread{Buy: buy, Sell: sell, Spread: spread})
+			}
+		}
+	}
+
+	sort.Sort(bySpread(spreads))
+	return spreads
+}
+
+type bySpread []*ArbitrageSpread
+
+func (s bySpread) Len() int           { return len(s) }
+func (s bySpread) Less(i, j int) bool { return s[i].Spread > s[j].Spread }
+func (s bySpread) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ScanArbitrage is GetArbitrageQuotes followed by FindArbitrageSpreads.
+func (session *Session) ScanArbitrage(
+	appID uint64,
+	country, marketHashName string,
+	currencyIDs []string,
+	baseCurrency string,
+	fx FXRateSource,
+	threshold float64,
+) ([]*ArbitrageSpread, error) {
+	quotes, err := session.GetArbitrageQuotes(appID, country, marketHashName, currencyIDs, baseCurrency, fx)
+	if err != nil {
+		return nil, err
+	}
+
+	return FindArbitrageSpreads(quotes, threshold), nil
+}
@@ -0,0 +1,145 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	apiEnumerateTokens    = "https://api.steampowered.com/IAuthenticationService/EnumerateTokens/v1/?"
+	apiRevokeRefreshToken = "https://api.steampowered.com/IAuthenticationService/RevokeRefreshToken/v1/?"
+	authTokenRevokeLogout = 1
+)
+
+var (
+	ErrCannotDeauthorize = errors.New("unable to deauthorize devices")
+	ErrNoWebAPIToken     = errors.New("no web api token, the session is not logged in")
+)
+
+type AuthSessionLocation struct {
+	Time    int64  `json:"time"`
+	Country string `json:"country"`
+	State   string `json:"state"`
+	City    string `json:"city"`
+}
+
+// AuthSession is one of the places the account is logged in from.
+type AuthSession struct {
+	TokenID          uint64               `json:"token_id,string"`
+	Description      string               `json:"token_description"`
+	TimeUpdated      int64                `json:"time_updated"`
+	PlatformType     int                  `json:"platform_type"`
+	LoggedIn         bool                 `json:"logged_in"`
+	OSPlatform       int                  `json:"os_platform"`
+	AuthType         int                  `json:"auth_type"`
+	GamingDeviceType int                  `json:"gaming_device_type"`
+	FirstSeen        *AuthSessionLocation `json:"first_seen"`
+	LastSeen         *AuthSessionLocation `json:"last_seen"`
+}
+
+// GetWebAPIToken returns the access token the community pages use for the
+// IAuthenticationService and other newer Web API services, the legacy OAuth
+// token of the login is refused by those.
+func (session *Session) GetWebAPIToken() (string, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/chat/clientjstoken")
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		LoggedIn bool   `json:"logged_in"`
+		Token    string `json:"token"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+
+	if !response.LoggedIn || len(response.Token) == 0 {
+		return "", ErrNoWebAPIToken
+	}
+
+	return response.Token, nil
+}
+
+// GetAuthSessions lists the sessions (refresh tokens) active on the account.
+func (session *Session) GetAuthSessions() ([]*AuthSession, error) {
+	token, err := session.GetWebAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := session.client.Get(apiEnumerateTokens + url.Values{
+		"access_token":         {token},
+		"include_revoked":      {"0"},
+		"include_non_web_auth": {"1"},
+	}.Encode())
+
+	type Tokens struct {
+		Tokens []*AuthSession `json:"refresh_tokens"`
+	}
+
+	type Response struct {
+		Inner Tokens `json:"response"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Inner.Tokens, nil
+}
+
+// RevokeAuthSession logs out the session @tokenID (see AuthSession.TokenID), the
+// other sessions are left alone, see DeauthorizeAllDevices for all of them.
+func (session *Session) RevokeAuthSession(tokenID uint64) error {
+	token, err := session.GetWebAPIToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := session.client.PostForm(apiRevokeRefreshToken+"access_token="+url.QueryEscape(token), url.Values{
+		"token_id":      {strconv.FormatUint(tokenID, 10)},
+		"steamid":       {session.oauth.SteamID.ToString()},
+		"revoke_action": {strconv.Itoa(authTokenRevokeLogout)},
+	})
+
+	return session.decodeServiceResponse(resp, err, &struct{}{})
+}
+
+// DeauthorizeAllDevices logs out every other session of the account, this
+// is the "Deauthorize all other devices" button of the account page.
+// Note: PrepareForSteamStore() must be called first.
+func (session *Session) DeauthorizeAllDevices() error {
+	resp, err := session.client.PostForm("https://store.steampowered.com/twofactor/manage_action", url.Values{
+		"action":    {"deauthorize"},
+		"sessionid": {session.sessionID},
+	})
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ErrCannotDeauthorize
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package steam
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	apiGetBadges                 = "https://api.steampowered.com/IPlayerService/GetBadges/v1/?"
+	apiGetCommunityBadgeProgress = "https://api.steampowered.com/IPlayerService/GetCommunityBadgeProgress/v1/?"
+)
+
+var (
+	cardDropsRegexp    = regexp.MustCompile("([0-9]+) card drops? remaining")
+	gameCardsURLRegexp = regexp.MustCompile("/gamecards/([0-9]+)")
+)
+
+// Badge AppID is only set for game badges, CommunityItemID for foil ones.
+type Badge struct {
+	BadgeID         uint32 `json:"badgeid"`
+	AppID           uint32 `json:"appid"`
+	Level           uint32 `json:"level"`
+	CompletionTime  int64  `json:"completion_time"`
+	XP              uint32 `json:"xp"`
+	Scarcity        uint32 `json:"scarcity"`
+	CommunityItemID uint64 `json:"communityitemid,string"`
+	BorderColor     uint32 `json:"border_color"`
+}
+
+type PlayerBadges struct {
+	Badges                     []*Badge `json:"badges"`
+	PlayerXP                   uint32   `json:"player_xp"`
+	PlayerLevel                uint32   `json:"player_level"`
+	PlayerXPNeededToLevelUp    uint32   `json:"player_xp_needed_to_level_up"`
+	PlayerXPNeededCurrentLevel uint32   `json:"player_xp_needed_current_level"`
+}
+
+type BadgeQuest struct {
+	QuestID   uint32 `json:"questid"`
+	Completed bool   `json:"completed"`
+}
+
+// CardDrops is what the badges page says about a game, Badge is nil if we
+// don't have its badge yet.
+type CardDrops struct {
+	AppID     uint32
+	Name      string
+	Remaining uint32
+	Badge     *Badge
+}
+
+func (session *Session) GetBadges(sid SteamID) (*PlayerBadges, error) {
+	resp, err := session.client.Get(apiGetBadges + url.Values{
+		"key":     {session.apiKey},
+		"steamid": {sid.ToString()},
+	}.Encode())
+
+	type Response struct {
+		Inner *PlayerBadges `json:"response"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Inner == nil {
+		return &PlayerBadges{}, nil
+	}
+
+	return response.Inner, nil
+}
+
+// GetCommunityBadgeProgress returns the quests of a community badge (e.g. the
+// Community Ambassador tasks).
+func (session *Session) GetCommunityBadgeProgress(sid SteamID, badgeID uint32) ([]*BadgeQuest, error) {
+	resp, err := session.client.Get(apiGetCommunityBadgeProgress + url.Values{
+		"key":     {session.apiKey},
+		"steamid": {sid.ToString()},
+		"badgeid": {strconv.FormatUint(uint64(badgeID), 10)},
+	}.Encode())
+
+	type Quests struct {
+		Quests []*BadgeQuest `json:"quests"`
+	}
+
+	type Response struct {
+		Inner Quests `json:"response"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Inner.Quests, nil
+}
+
+// getBadgesPage scrapes a page of our badges page for the card drops, it returns
+// how many pages there are.
+func (session *Session) getBadgesPage(page int, drops map[uint32]*CardDrops) (int, error) {
+	resp, err := session.client.Get(fmt.Sprintf(
+		"https://steamcommunity.com/profiles/%s/badges/?l=english&p=%d",
+		session.oauth.SteamID.ToString(),
+		page,
+	))
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	doc.Find(".badge_row").Each(func(i int, row *goquery.Selection) {
+		m := gameCardsURLRegexp.FindStringSubmatch(row.Find(".badge_row_overlay").AttrOr("href", ""))
+		if m == nil || strings.Contains(row.Find(".badge_row_overlay").AttrOr("href", ""), "border=1") {
+			return
+		}
+
+		appID, err := strconv.ParseUint(m[1], 10, 32)
+		if err != nil {
+			return
+		}
+
+		remaining := uint32(0)
+		if drop := cardDropsRegexp.FindStringSubmatch(row.Find(".progress_info_bold").Text()); drop != nil {
+			count, _ := strconv.ParseUint(drop[1], 10, 32)
+			remaining = uint32(count)
+		}
+
+		drops[uint32(appID)] = &CardDrops{
+			AppID:     uint32(appID),
+			Name:      strings.TrimSpace(row.Find(".badge_title").Contents().First().Text()),
+			Remaining: remaining,
+		}
+	})
+
+	pages := 1
+	doc.Find(".pagelink").Each(func(i int, s *goquery.Selection) {
+		if n, err := strconv.Atoi(strings.TrimSpace(s.Text())); err == nil && n > pages {
+			pages = n
+		}
+	})
+
+	return pages, nil
+}
+
+// GetCardDrops combines our badges page (for the remaining card drops, which no API
+// has) with GetBadges, only the games with drops remaining are returned.
+func (session *Session) GetCardDrops() ([]*CardDrops, error) {
+	drops := make(map[uint32]*CardDrops)
+	for page, pages := 1, 1; page <= pages; page++ {
+		var err error
+		if pages, err = session.getBadgesPage(page, drops); err != nil {
+			return nil, err
+		}
+	}
+
+	badges, err := session.GetBadges(session.oauth.SteamID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, badge := range badges.Badges {
+		if drop, ok := drops[badge.AppID]; ok && badge.AppID != 0 && badge.BorderColor == 0 {
+			drop.Badge = badge
+		}
+	}
+
+	result := []*CardDrops{}
+	for _, drop := range drops {
+		if drop.Remaining != 0 {
+			result = append(result, drop)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package steam
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Most ISteamUser methods taking a list of SteamIDs refuse more than this many.
+const SteamIDBatchSize = 100
+
+// SteamIDBatchError is the error of a single chunk of a batched call.
+type SteamIDBatchError struct {
+	SteamIDs []SteamID
+	Err      error
+}
+
+// SteamIDBatchErrors are the chunks of a batched call that failed, the results of
+// the other chunks are still returned along with it.
+type SteamIDBatchErrors []*SteamIDBatchError
+
+func (errs SteamIDBatchErrors) Error() string {
+	if len(errs) == 1 {
+		return fmt.Sprintf("%d SteamID(s) failed: %v", len(errs[0].SteamIDs), errs[0].Err)
+	}
+
+	failed := 0
+	for _, err := range errs {
+		failed += len(err.SteamIDs)
+	}
+
+	return fmt.Sprintf("%d SteamID(s) failed in %d batches, first error: %v", failed, len(errs), errs[0].Err)
+}
+
+// Unwrap returns the error of the first failed chunk.
+func (errs SteamIDBatchErrors) Unwrap() error {
+	return errs[0].Err
+}
+
+// ChunkSteamIDs splits @sids in chunks of at most @size (SteamIDBatchSize if 0 or
+// less), the chunks share the memory of @sids.
+func ChunkSteamIDs(sids []SteamID, size int) [][]SteamID {
+	if size <= 0 {
+		size = SteamIDBatchSize
+	}
+
+	chunks := make([][]SteamID, 0, (len(sids)+size-1)/size)
+	for len(sids) > size {
+		chunks = append(chunks, sids[:size:size])
+		sids = sids[size:]
+	}
+
+	if len(sids) != 0 {
+		chunks = append(chunks, sids)
+	}
+
+	return chunks
+}
+
+func joinSteamIDs(sids []SteamID) string {
+	ids := make([]string, len(sids))
+	for i := range sids {
+		ids[i] = sids[i].ToString()
+	}
+
+	return strings.Join(ids, ",")
+}
+
+// batchSteamIDs calls @fetch for every chunk of @sids, the chunks failing are
+// returned as SteamIDBatchErrors (nil if none did).
+func batchSteamIDs(sids []SteamID, fetch func(steamids string) error) error {
+	var errs SteamIDBatchErrors
+	for _, chunk := range ChunkSteamIDs(sids, SteamIDBatchSize) {
+		if err := fetch(joinSteamIDs(chunk)); err != nil {
+			errs = append(errs, &SteamIDBatchError{SteamIDs: chunk, Err: err})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// GetPlayerSummariesBatched is GetPlayerSummaries for any number of @sids, on
+// errors the summaries of the chunks that succeeded are returned along with
+// SteamIDBatchErrors.
+func (session *Session) GetPlayerSummariesBatched(sids []SteamID) ([]*PlayerSummary, error) {
+	summaries := make([]*PlayerSummary, 0, len(sids))
+	err := batchSteamIDs(sids, func(steamids string) error {
+		chunk, err := session.GetPlayerSummaries(steamids)
+		summaries = append(summaries, chunk...)
+		return err
+	})
+
+	return summaries, err
+}
+
+// GetPlayerBansBatched is GetPlayerBans for any number of @sids, see
+// GetPlayerSummariesBatched.
+func (session *Session) GetPlayerBansBatched(sids []SteamID) ([]*PlayerBan, error) {
+	bans := make([]*PlayerBan, 0, len(sids))
+	err := batchSteamIDs(sids, func(steamids string) error {
+		chunk, err := session.GetPlayerBans(steamids)
+		bans = append(bans, chunk...)
+		return err
+	})
+
+	return bans, err
+}
@@ -0,0 +1,104 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+var (
+	ErrCannotBlockUser  = errors.New("unable to block user")
+	ErrCannotReportUser = errors.New("unable to report user")
+)
+
+func (session *Session) setBlocked(sid SteamID, block bool) error {
+	flag := "0"
+	if block {
+		flag = "1"
+	}
+
+	resp, err := session.client.PostForm("https://steamcommunity.com/actions/BlockUserAjax", url.Values{
+		"sessionID": {session.sessionID},
+		"steamid":   {sid.ToString()},
+		"block":     {flag},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int `json:"success"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Success != 1 {
+		return ErrCannotBlockUser
+	}
+
+	return nil
+}
+
+// BlockUser blocks all communication with @sid (what the profile calls ignoring),
+// it also removes them from our friends.
+func (session *Session) BlockUser(sid SteamID) error {
+	return session.setBlocked(sid, true)
+}
+
+func (session *Session) UnblockUser(sid SteamID) error {
+	return session.setBlocked(sid, false)
+}
+
+// ReportUser files an abuse report against @sid like the report form of the profile,
+// @abuseType is the eAbuseType the form sends for the chosen reason and @appID the
+// game it's about (0 for none).
+func (session *Session) ReportUser(sid SteamID, abuseType int, description string, appID uint32) error {
+	resp, err := session.client.PostForm("https://steamcommunity.com/actions/ReportAbuse/", url.Values{
+		"sessionid":        {session.sessionID},
+		"json":             {"1"},
+		"abuseID":          {sid.ToString()},
+		"eAbuseType":       {strconv.Itoa(abuseType)},
+		"abuseDescription": {description},
+		"ingameAppID":      {strconv.FormatUint(uint64(appID), 10)},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int `json:"success"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Success != 1 {
+		return ErrCannotReportUser
+	}
+
+	return nil
+}
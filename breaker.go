@@ -0,0 +1,87 @@
+package steam
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit open: endpoint is failing, try again later")
+
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// CircuitBreaker fast-fails the requests to an endpoint (host and path, the
+// query is ignored) for a cool-down period once it failed Threshold times in
+// a row, e.g. during market maintenance.  A failure is a transport error, a
+// 5xx or a 429.  After the cool-down, a single failure trips it again.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	circuits map[string]*circuit
+	mutex    sync.Mutex
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+// SetCircuitBreaker makes every request of the session go through @breaker, nil disables it.
+func (session *Session) SetCircuitBreaker(breaker *CircuitBreaker) {
+	session.breaker = breaker
+}
+
+func (breaker *CircuitBreaker) allow(key string) error {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if c, ok := breaker.circuits[key]; ok && time.Now().Before(c.openUntil) {
+		return ErrCircuitOpen
+	}
+
+	return nil
+}
+
+func (breaker *CircuitBreaker) record(key string, failed bool) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if !failed {
+		delete(breaker.circuits, key)
+		return
+	}
+
+	c, ok := breaker.circuits[key]
+	if !ok {
+		c = &circuit{}
+		breaker.circuits[key] = c
+	}
+
+	c.failures++
+	if c.failures >= breaker.Threshold {
+		c.openUntil = time.Now().Add(breaker.Cooldown)
+	}
+}
+
+// IsOpen tells whether requests to @rawURL are currently failing fast.
+func (breaker *CircuitBreaker) IsOpen(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return breaker.allow(u.Host+u.Path) != nil
+}
+
+func requestFailed(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
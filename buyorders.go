@@ -0,0 +1,259 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MarketBuyOrderResponse.ErrCode values.
+const (
+	BuyOrderResultOK              = 1
+	BuyOrderResultLimitExceeded   = 25
+	BuyOrderResultAlreadyHasOrder = 29
+)
+
+var (
+	ErrBuyOrderExists       = errors.New("a buy order already exists for this item")
+	ErrBuyOrderNotFound     = errors.New("buy order not found")
+	ErrBuyOrderNotCancelled = errors.New("buy order is still active after cancelling it")
+	ErrBuyOrderLimit        = errors.New("buy orders would exceed the wallet limit")
+)
+
+// Steam refuses buy orders once all of them together are worth more than this
+// many times the wallet balance.
+const BuyOrderBalanceMultiplier = 10
+
+type BuyOrderRequest struct {
+	AppID          uint64
+	MarketHashName string
+	PriceTotal     float64
+	Quantity       uint64
+	CurrencyID     string
+
+	// OrderID is set once the order is placed, requests that have it
+	// are skipped, so the same slice can be passed again to resume.
+	OrderID uint64
+}
+
+type BuyOrderResult struct {
+	Request  *BuyOrderRequest
+	Response *MarketBuyOrderResponse
+	Err      error
+}
+
+func (response *MarketBuyOrderResponse) error() error {
+	switch response.ErrCode {
+	case BuyOrderResultOK:
+		return nil
+	case BuyOrderResultAlreadyHasOrder:
+		return ErrBuyOrderExists
+	case BuyOrderResultLimitExceeded:
+		return ErrBuyOrderLimit
+	}
+
+	if len(response.ErrMsg) != 0 {
+		return errors.New(response.ErrMsg)
+	}
+
+	return fmt.Errorf("cannot place buy order: %d", response.ErrCode)
+}
+
+// PlaceBuyOrders places the orders one after another (so they are subject to the
+// session's rate limiter, if any) and returns one result per request, in order.
+// A failure does not stop the remaining requests.
+func (session *Session) PlaceBuyOrders(requests []*BuyOrderRequest) []*BuyOrderResult {
+	results := make([]*BuyOrderResult, len(requests))
+	for i, request := range requests {
+		result := &BuyOrderResult{Request: request}
+		results[i] = result
+
+		if request.OrderID != 0 {
+			continue
+		}
+
+		result.Response, result.Err = session.PlaceBuyOrder(
+			request.AppID,
+			request.PriceTotal,
+			request.Quantity,
+			request.CurrencyID,
+			request.MarketHashName,
+		)
+		if result.Err != nil {
+			continue
+		}
+
+		if result.Err = result.Response.error(); result.Err == nil {
+			request.OrderID = result.Response.OrderID
+		}
+	}
+
+	return results
+}
+
+// BuyOrderModifyError is returned by ModifyBuyOrder when the old order was cancelled
+// but the new one couldn't be placed.  The old order is then placed again (for
+// the quantity that was remaining), Restored is the result unless RestoreErr.
+type BuyOrderModifyError struct {
+	Err        error
+	Old        *MarketBuyOrder
+	Restored   *MarketBuyOrderResponse
+	RestoreErr error
+}
+
+func (err *BuyOrderModifyError) Error() string {
+	if err.RestoreErr != nil {
+		return fmt.Sprintf("cannot place modified buy order: %v, old order lost: %v", err.Err, err.RestoreErr)
+	}
+
+	return fmt.Sprintf("cannot place modified buy order: %v, old order restored", err.Err)
+}
+
+func (err *BuyOrderModifyError) Unwrap() error {
+	return err.Err
+}
+
+func (session *Session) findBuyOrder(orderID uint64) (*MarketBuyOrder, error) {
+	// Buy orders are always returned in full, whatever the count.
+	listings, err := session.GetMyListings(0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, order := range listings.BuyOrders {
+		if order.OrderID == orderID {
+			return order, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (session *Session) placeBuyOrder(order *MarketBuyOrder, price, quantity uint64) (*MarketBuyOrderResponse, error) {
+	response, err := session.placeBuyOrderCents(
+		uint64(order.AppID),
+		price*quantity,
+		quantity,
+		strconv.FormatUint(uint64(order.WalletCurrency), 10),
+		order.HashName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, response.error()
+}
+
+// ModifyBuyOrder changes the price (in cents, per item) and quantity of a buy order.
+// Steam cannot edit orders, so it cancels it, checks that it's gone and places a new
+// one, the new order ID is in the response.  See BuyOrderModifyError for when the
+// last step fails.
+func (session *Session) ModifyBuyOrder(orderID, newPrice, newQuantity uint64) (*MarketBuyOrderResponse, error) {
+	old, err := session.findBuyOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if old == nil {
+		return nil, ErrBuyOrderNotFound
+	}
+
+	if err = session.CancelBuyOrder(orderID); err != nil {
+		return nil, err
+	}
+
+	if order, err := session.findBuyOrder(orderID); err != nil {
+		return nil, err
+	} else if order != nil {
+		return nil, ErrBuyOrderNotCancelled
+	}
+
+	response, err := session.placeBuyOrder(old, newPrice, newQuantity)
+	if err == nil {
+		return response, nil
+	}
+
+	modifyErr := &BuyOrderModifyError{Err: err, Old: old}
+	modifyErr.Restored, modifyErr.RestoreErr = session.placeBuyOrder(old, old.Price, old.QuantityRemaining)
+	return nil, modifyErr
+}
+
+// BuyOrderLimitError is returned by CheckBuyOrder when the order doesn't fit,
+// all amounts are in cents, Available is how much more can still be ordered.
+type BuyOrderLimitError struct {
+	Total       uint64
+	Outstanding uint64
+	Balance     uint64
+	Limit       uint64
+	Available   uint64
+}
+
+func (err *BuyOrderLimitError) Error() string {
+	return fmt.Sprintf(
+		"buy order of %d exceeds the wallet limit: %d already ordered, limit %d (%dx balance of %d), %d available",
+		err.Total, err.Outstanding, err.Limit, BuyOrderBalanceMultiplier, err.Balance, err.Available,
+	)
+}
+
+func (err *BuyOrderLimitError) Unwrap() error {
+	return ErrBuyOrderLimit
+}
+
+// CheckBuyOrder checks locally what Steam would refuse a new order for: an order
+// already existing for the item (ErrBuyOrderExists) or all the orders together
+// being over the wallet limit (*BuyOrderLimitError), @priceTotal in cents.
+func (session *Session) CheckBuyOrder(appID uint64, marketHashName string, priceTotal uint64) error {
+	info, err := session.GetWalletInfo()
+	if err != nil {
+		return err
+	}
+
+	listings, err := session.GetMyListings(0, 1)
+	if err != nil {
+		return err
+	}
+
+	outstanding := uint64(0)
+	for _, order := range listings.BuyOrders {
+		if uint64(order.AppID) == appID && order.HashName == marketHashName {
+			return ErrBuyOrderExists
+		}
+
+		outstanding += order.Price * order.QuantityRemaining
+	}
+
+	limit := info.Balance * BuyOrderBalanceMultiplier
+	if outstanding+priceTotal > limit {
+		available := uint64(0)
+		if limit > outstanding {
+			available = limit - outstanding
+		}
+
+		return &BuyOrderLimitError{
+			Total:       priceTotal,
+			Outstanding: outstanding,
+			Balance:     info.Balance,
+			Limit:       limit,
+			Available:   available,
+		}
+	}
+
+	return nil
+}
+
+// PlaceBuyOrderChecked is PlaceBuyOrder after CheckBuyOrder, the errors of the
+// response are returned too.
+func (session *Session) PlaceBuyOrderChecked(appID uint64, priceTotal float64, quantity uint64, currencyID, marketHashName string) (*MarketBuyOrderResponse, error) {
+	cents := uint64(math.Round(priceTotal * 100))
+	if err := session.CheckBuyOrder(appID, marketHashName, cents); err != nil {
+		return nil, err
+	}
+
+	response, err := session.placeBuyOrderCents(appID, cents, quantity, currencyID, marketHashName)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, response.error()
+}
@@ -0,0 +1,162 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrNoPurchaseLimit    = errors.New("a purchase needs a maximum total")
+	ErrPurchaseOverLimit  = errors.New("purchase total is over the maximum")
+	ErrInsufficientFunds  = errors.New("wallet balance is too low for the purchase")
+	ErrSubNotAllowed      = errors.New("package is not in the allowed list")
+	ErrEmptyPurchaseOrder = errors.New("nothing to purchase")
+)
+
+// AddToCart adds the package @subID to the store cart, see ShoppingCartID.
+func (session *Session) AddToCart(subID uint64) error {
+	resp, err := session.client.PostForm("https://store.steampowered.com/cart/", url.Values{
+		"action":    {"add_to_cart"},
+		"subid":     {strconv.FormatUint(subID, 10)},
+		"sessionid": {session.sessionID},
+	})
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ForgetShoppingCart drops the cart cookie so that the next AddToCart starts a
+// new, empty, cart.
+func (session *Session) ForgetShoppingCart() {
+	store, _ := url.Parse("https://store.steampowered.com")
+	session.client.Jar.SetCookies(store, []*http.Cookie{{
+		Name:    "shoppingCartGID",
+		Path:    "/",
+		MaxAge:  -1,
+		Expires: time.Unix(1, 0),
+	}})
+}
+
+// PurchaseOrder is a wallet purchase of SubIDs, for us or as a gift (see
+// CheckoutOptions).  MaxTotal (in cents of the wallet currency) is required, the
+// purchase is cancelled if the final price is over it.  If AllowedSubIDs is not
+// empty, only those packages can be bought.  DryRun stops after getting the final
+// price and cancels the transaction.
+type PurchaseOrder struct {
+	SubIDs        []uint64
+	Giftee        *SteamID
+	Message       *GiftMessage
+	CouponID      uint64
+	MaxTotal      int64
+	AllowedSubIDs []uint64
+	DryRun        bool
+}
+
+type PurchaseResult struct {
+	Order       *PurchaseOrder
+	Transaction *StoreTransaction
+	Price       *StoreFinalPrice
+	// Finalized is false for dry runs.
+	Finalized bool
+}
+
+func (order *PurchaseOrder) check() error {
+	if len(order.SubIDs) == 0 {
+		return ErrEmptyPurchaseOrder
+	}
+
+	if order.MaxTotal <= 0 {
+		return ErrNoPurchaseLimit
+	}
+
+	if len(order.AllowedSubIDs) == 0 {
+		return nil
+	}
+
+	allowed := make(map[uint64]bool, len(order.AllowedSubIDs))
+	for _, subID := range order.AllowedSubIDs {
+		allowed[subID] = true
+	}
+
+	for _, subID := range order.SubIDs {
+		if !allowed[subID] {
+			return ErrSubNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// Purchase buys @order from a new cart with the wallet, checking its limits and the
+// wallet balance against the final price before paying.  The transaction is
+// cancelled when a check fails, the result is returned along with the error
+// once there is one.  This is a store request, see PrepareForSteamStore.
+func (session *Session) Purchase(order *PurchaseOrder) (*PurchaseResult, error) {
+	if err := order.check(); err != nil {
+		return nil, err
+	}
+
+	session.ForgetShoppingCart()
+	for _, subID := range order.SubIDs {
+		if err := session.AddToCart(subID); err != nil {
+			return nil, err
+		}
+	}
+
+	transaction, err := session.InitTransaction(&CheckoutOptions{
+		CouponID: order.CouponID,
+		Giftee:   order.Giftee,
+		Message:  order.Message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PurchaseResult{Order: order, Transaction: transaction}
+	if result.Price, err = session.GetFinalPrice(transaction); err != nil {
+		session.CancelTransaction(transaction)
+		return result, err
+	}
+
+	if result.Price.Total > order.MaxTotal {
+		session.CancelTransaction(transaction)
+		return result, ErrPurchaseOverLimit
+	}
+
+	info, err := session.GetWalletInfo()
+	if err != nil {
+		session.CancelTransaction(transaction)
+		return result, err
+	}
+
+	if result.Price.Total < 0 || uint64(result.Price.Total) > info.Balance {
+		session.CancelTransaction(transaction)
+		return result, ErrInsufficientFunds
+	}
+
+	if order.DryRun {
+		return result, session.CancelTransaction(transaction)
+	}
+
+	if err = session.FinalizeTransaction(transaction); err != nil {
+		return result, err
+	}
+
+	result.Finalized = true
+	session.ForgetShoppingCart()
+	return result, nil
+}
@@ -28,9 +28,10 @@ const (
 )
 
 const (
-	MessageTypeStatus  = "personastate"
-	MessageTypeTyping  = "typing"
-	MessageTypeSayText = "saytext"
+	MessageTypeStatus       = "personastate"
+	MessageTypeTyping       = "typing"
+	MessageTypeSayText      = "saytext"
+	MessageTypeRelationship = "personarelationship"
 )
 
 const (
@@ -94,20 +95,9 @@ func (session *Session) ChatLogin(uiMode string) error {
 		"ui_mode":      {uiMode},
 		"access_token": {session.oauth.Token},
 	})
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("http error: %d", resp.StatusCode)
-	}
 
 	var response ChatResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return err
 	}
 
@@ -133,6 +123,10 @@ func (session *Session) ChatLogoff() error {
 		return err
 	}
 
+	if _, err = checkEResult(resp); err != nil {
+		return err
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("http error: %d", resp.StatusCode)
 	}
@@ -148,16 +142,9 @@ func (session *Session) ChatSendMessage(sid SteamID, message, messageType string
 		"type":         {messageType},
 		"umqid":        {session.umqID},
 	})
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return err
-	}
 
 	var response ChatResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return err
 	}
 
@@ -178,21 +165,16 @@ func (session *Session) ChatPoll(timeoutSeconds string) (*ChatResponse, error) {
 		"secidletime":    {"0"},
 		"use_accountids": {"1"},
 	})
-	if resp != nil {
-		defer resp.Body.Close()
-	}
 
-	if err != nil {
+	response := &ChatResponse{}
+	if err = session.decodeAPIResponse(resp, err, response); err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
-	}
-
-	response := &ChatResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
-		return nil, err
+	if response.ErrorMessage == "OK" {
+		// Acknowledge what we've got so far, otherwise the next poll
+		// returns the same messages again.
+		session.chatMessage = int(response.LastMessages)
 	}
 
 	return response, nil
@@ -239,3 +221,40 @@ func (session *Session) ChatLog(partner uint32) ([]*ChatLogMessage, error) {
 
 	return log, nil
 }
+
+func (session *Session) ChatSendText(sid SteamID, message string) error {
+	return session.ChatSendMessage(sid, message, MessageTypeSayText)
+}
+
+// ChatSendTyping notifies @sid that we're typing, this is shown for a few seconds only
+// so it has to be repeated while typing.
+func (session *Session) ChatSendTyping(sid SteamID) error {
+	return session.ChatSendMessage(sid, "", MessageTypeTyping)
+}
+
+// ChatOfflineMessages returns the messages @sid sent that were not seen yet, i.e. the ones
+// sent while we were offline.
+func (session *Session) ChatOfflineMessages(sid SteamID) ([]*ChatLogMessage, error) {
+	state, err := session.ChatFriendState(sid)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.LastMessage <= state.LastView {
+		return []*ChatLogMessage{}, nil
+	}
+
+	log, err := session.ChatLog(sid.GetAccountID())
+	if err != nil {
+		return nil, err
+	}
+
+	unread := []*ChatLogMessage{}
+	for _, message := range log {
+		if message.Partner == sid.GetAccountID() && message.Timestamp > state.LastView {
+			unread = append(unread, message)
+		}
+	}
+
+	return unread, nil
+}
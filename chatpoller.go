@@ -0,0 +1,176 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	chatStatusOK          = "OK"
+	chatStatusTimeout     = "Timeout"
+	chatStatusNotLoggedOn = "Not Logged On"
+)
+
+var ErrChatPollerStarted = errors.New("chat poller already started")
+
+// ChatPoller long-polls the legacy web chat API and streams what it returns
+// (persona states, chat messages, typing notifications) to a channel.  This is
+// polling, not a push connection: only one request is in flight at a time, it
+// returns after at most PollTimeout and is retried with an exponential backoff
+// whenever it fails.  The pending trade offers are polled separately every
+// TradeOfferInterval, see TradeOffers.
+// Note: This is optional, nothing in the package starts it for you.
+type ChatPoller struct {
+	session *Session
+	uiMode  string
+
+	PollTimeout time.Duration
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	// TradeOfferInterval is how often the pending trade offers are checked, 0 to
+	// never check them.
+	TradeOfferInterval time.Duration
+	Clock              Clock
+
+	messages    chan *ChatMessage
+	tradeOffers chan uint32
+	errors      chan error
+	lifecycle
+}
+
+func (session *Session) NewChatPoller(uiMode string) *ChatPoller {
+	return &ChatPoller{
+		session:            session,
+		uiMode:             uiMode,
+		PollTimeout:        30 * time.Second,
+		MinBackoff:         time.Second,
+		MaxBackoff:         time.Minute,
+		TradeOfferInterval: 30 * time.Second,
+		Clock:              SystemClock,
+		messages:           make(chan *ChatMessage, 64),
+		tradeOffers:        make(chan uint32, 8),
+		errors:             make(chan error, 1),
+	}
+}
+
+// Messages is the same channel for every Start, it's never closed so that readers
+// keep getting messages when the poller is stopped and started again.
+func (poller *ChatPoller) Messages() <-chan *ChatMessage {
+	return poller.messages
+}
+
+// TradeOffers gets the number of pending trade offers whenever it goes up, it's
+// the same channel for every Start and is never closed, like Messages.
+func (poller *ChatPoller) TradeOffers() <-chan uint32 {
+	return poller.tradeOffers
+}
+
+// Errors reports poll errors, they are not fatal, the poll is retried after them.  Errors are dropped if nobody is reading.
+func (poller *ChatPoller) Errors() <-chan error {
+	return poller.errors
+}
+
+// Start logs in the presence session and polls until Stop or @ctx is done, the poll
+// in flight (if any) is let to finish and then the presence session is logged off.
+func (poller *ChatPoller) Start(ctx context.Context) error {
+	return poller.start(ctx, ErrChatPollerStarted, func() error {
+		return poller.session.ChatLogin(poller.uiMode)
+	}, poller.run)
+}
+
+func (poller *ChatPoller) reportError(err error) {
+	select {
+	case poller.errors <- err:
+	default:
+	}
+}
+
+func (poller *ChatPoller) poll(stop chan struct{}) error {
+	response, err := poller.session.ChatPoll(strconv.FormatInt(int64(poller.PollTimeout/time.Second), 10))
+	if err != nil {
+		return err
+	}
+
+	switch response.ErrorMessage {
+	case chatStatusOK:
+	case chatStatusTimeout:
+		return nil
+	case chatStatusNotLoggedOn:
+		return poller.session.ChatLogin(poller.uiMode)
+	default:
+		return errors.New(response.ErrorMessage)
+	}
+
+	for _, message := range response.Messages {
+		select {
+		case poller.messages <- message:
+		case <-stop:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// checkTradeOffers sends the pending trade offers count if it went up since @last,
+// it returns false if @stop is closed first.
+func (poller *ChatPoller) checkTradeOffers(stop chan struct{}, last *uint32) bool {
+	counts, err := poller.session.GetNotificationCounts()
+	if err != nil {
+		poller.reportError(err)
+		return true
+	}
+
+	pending := counts.TradeOffers
+	if pending <= *last {
+		*last = pending
+		return true
+	}
+
+	select {
+	case poller.tradeOffers <- pending:
+		*last = pending
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+func (poller *ChatPoller) run(stop chan struct{}) {
+	defer poller.session.ChatLogoff()
+
+	var checked time.Time
+	var tradeOffers uint32
+	backoff := poller.MinBackoff
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		if poller.TradeOfferInterval > 0 && clockOrSystem(poller.Clock).Now().Sub(checked) >= poller.TradeOfferInterval {
+			checked = clockOrSystem(poller.Clock).Now()
+			if !poller.checkTradeOffers(stop, &tradeOffers) {
+				return
+			}
+		}
+
+		if err := poller.poll(stop); err != nil {
+			poller.reportError(err)
+
+			if !wait(poller.Clock, stop, backoff) {
+				return
+			}
+
+			if backoff *= 2; backoff > poller.MaxBackoff {
+				backoff = poller.MaxBackoff
+			}
+			continue
+		}
+
+		backoff = poller.MinBackoff
+	}
+}
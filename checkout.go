@@ -0,0 +1,309 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Coupons are in the Steam inventory (app 753) like community items, in their
+// own context.
+const SteamCouponContextID = 3
+
+// How many times FinalizeTransaction asks for the status of the transaction.
+const transactionStatusAttempts = 10
+
+var (
+	ErrNoShoppingCart            = errors.New("no shopping cart, add something to the cart first")
+	ErrCannotInitTransaction     = errors.New("unable to initialize transaction")
+	ErrCannotGetFinalPrice       = errors.New("unable to get the final price of the transaction")
+	ErrCannotFinalizeTransaction = errors.New("unable to finalize transaction")
+	ErrTransactionPending        = errors.New("transaction is still pending")
+)
+
+// CheckoutOptions CouponID is the asset ID of the coupon to apply (see GetCoupons),
+// 0 for none.  With Giftee set the cart is bought as a gift for them, Message
+// may be nil.
+type CheckoutOptions struct {
+	CartID   string
+	CouponID uint64
+	Giftee   *SteamID
+	Message  *GiftMessage
+}
+
+type StoreTransaction struct {
+	ID      string
+	Options *CheckoutOptions
+}
+
+// StoreFinalPrice amounts are in cents of the wallet currency.
+type StoreFinalPrice struct {
+	Base           int64  `json:"base,string"`
+	Tax            int64  `json:"tax,string"`
+	Discount       int64  `json:"discount,string"`
+	Total          int64  `json:"total"`
+	FormattedTotal string `json:"formattedTotal"`
+	Success        int    `json:"success"`
+}
+
+// ShoppingCartID returns the ID of the store cart, which is kept in a cookie.
+func (session *Session) ShoppingCartID() (string, error) {
+	store, _ := url.Parse("https://store.steampowered.com")
+	for _, cookie := range session.client.Jar.Cookies(store) {
+		if cookie.Name == "shoppingCartGID" && len(cookie.Value) != 0 && cookie.Value != "-1" {
+			return cookie.Value, nil
+		}
+	}
+
+	return "", ErrNoShoppingCart
+}
+
+// GetCoupons returns the coupons of our inventory.
+func (session *Session) GetCoupons() ([]InventoryItem, error) {
+	return session.GetInventory(session.oauth.SteamID, SteamCommunityAppID, SteamCouponContextID, false)
+}
+
+// InitTransaction starts paying for the cart with the wallet, @options.CartID may be
+// empty for the current cart.  This is a store request, see PrepareForSteamStore.
+func (session *Session) InitTransaction(options *CheckoutOptions) (*StoreTransaction, error) {
+	if len(options.CartID) == 0 {
+		cartID, err := session.ShoppingCartID()
+		if err != nil {
+			return nil, err
+		}
+		options.CartID = cartID
+	}
+
+	params := url.Values{
+		"gidShoppingCart":           {options.CartID},
+		"gidReplayOfTransID":        {"-1"},
+		"PaymentMethod":             {"steamaccount"},
+		"abortPendingTransactions":  {"0"},
+		"bHasCardInfo":              {"0"},
+		"bIsGift":                   {"0"},
+		"bSaveBillingAddress":       {"1"},
+		"bUseRemainingSteamAccount": {"1"},
+		"bPreAuthOnly":              {"0"},
+		"sessionid":                 {session.sessionID},
+	}
+	if options.CouponID != 0 {
+		params.Set("gidCoupon", strconv.FormatUint(options.CouponID, 10))
+	}
+
+	if options.Giftee != nil {
+		message := options.Message
+		if message == nil {
+			message = &GiftMessage{}
+		}
+
+		params.Set("bIsGift", "1")
+		params.Set("GifteeAccountID", strconv.FormatUint(uint64(options.Giftee.GetAccountID()), 10))
+		params.Set("GifteeEmail", "")
+		params.Set("GifteeName", message.Name)
+		params.Set("GiftMessage", message.Message)
+		params.Set("Sentiment", message.Sentiment)
+		params.Set("Signature", message.Signature)
+		params.Set("ScheduledSendOnDate", "0")
+	}
+
+	resp, err := session.client.PostForm("https://store.steampowered.com/checkout/inittransaction/", params)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int    `json:"success"`
+		TransID string `json:"transid"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if response.Success != 1 || len(response.TransID) == 0 {
+		return nil, ErrCannotInitTransaction
+	}
+
+	return &StoreTransaction{ID: response.TransID, Options: options}, nil
+}
+
+// GetFinalPrice is what the transaction will cost, coupon and taxes included.
+func (session *Session) GetFinalPrice(transaction *StoreTransaction) (*StoreFinalPrice, error) {
+	purchaseType := "self"
+	if transaction.Options.Giftee != nil {
+		purchaseType = "gift"
+	}
+
+	params := url.Values{
+		"count":              {"1"},
+		"transid":            {transaction.ID},
+		"purchasetype":       {purchaseType},
+		"microtxnid":         {"-1"},
+		"cart":               {transaction.Options.CartID},
+		"gidReplayOfTransID": {"-1"},
+	}
+
+	resp, err := session.client.Get("https://store.steampowered.com/checkout/getfinalprice/?" + params.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	price := &StoreFinalPrice{}
+	if err = json.NewDecoder(resp.Body).Decode(price); err != nil {
+		return nil, err
+	}
+
+	if price.Success != 1 {
+		return nil, ErrCannotGetFinalPrice
+	}
+
+	return price, nil
+}
+
+func (session *Session) transactionStatus(transaction *StoreTransaction) (int, error) {
+	resp, err := session.client.Get("https://store.steampowered.com/checkout/transactionstatus/?" + url.Values{
+		"count":   {"1"},
+		"transid": {transaction.ID},
+	}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int `json:"success"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return 0, err
+	}
+
+	return response.Success, nil
+}
+
+// FinalizeTransaction pays and waits for Steam to complete the purchase,
+// ErrTransactionPending is returned if it's still not done after a few seconds.
+func (session *Session) FinalizeTransaction(transaction *StoreTransaction) error {
+	resp, err := session.client.PostForm("https://store.steampowered.com/checkout/finalizetransaction/", url.Values{
+		"transid":  {transaction.ID},
+		"CardCVV2": {""},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int `json:"success"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	// 22 means it's being processed.
+	if response.Success != 1 && response.Success != 22 {
+		return ErrCannotFinalizeTransaction
+	}
+
+	for i := 0; i < transactionStatusAttempts; i++ {
+		status, err := session.transactionStatus(transaction)
+		if err != nil {
+			return err
+		}
+
+		switch status {
+		case 1:
+			return nil
+		case 22:
+			time.Sleep(time.Second)
+		default:
+			return ErrCannotFinalizeTransaction
+		}
+	}
+
+	return ErrTransactionPending
+}
+
+// CancelTransaction abandons a transaction that wasn't finalized.
+func (session *Session) CancelTransaction(transaction *StoreTransaction) error {
+	resp, err := session.client.PostForm("https://store.steampowered.com/checkout/canceltransaction/", url.Values{
+		"transid":   {transaction.ID},
+		"sessionid": {session.sessionID},
+	})
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// CheckoutWithCoupon buys the current cart with @coupon applied (nil for none),
+// the final price is returned once it's paid.
+func (session *Session) CheckoutWithCoupon(coupon *InventoryItem) (*StoreFinalPrice, error) {
+	options := &CheckoutOptions{}
+	if coupon != nil {
+		options.CouponID = coupon.AssetID
+	}
+
+	transaction, err := session.InitTransaction(options)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := session.GetFinalPrice(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = session.FinalizeTransaction(transaction); err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
@@ -0,0 +1,56 @@
+package steam
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Clock is what the background components (PriceWatcher, TradeOfferManager,
+// EventDispatcher, SessionPool, ChatPoller) take the time from and wait with, so
+// that tests can move time forward themselves instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	// After is like time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock is the real time, used when a nil Clock is given.
+var SystemClock Clock = systemClock{}
+
+func clockOrSystem(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock
+	}
+
+	return clock
+}
+
+// Jitter returns @d randomly moved by up to @fraction of it either way (e.g. 0.1
+// for ±10%), so that pollers started together don't stay in step.
+func Jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+
+	return d + time.Duration((rand.Float64()*2-1)*fraction*float64(d))
+}
+
+// wait waits @d on @clock, it returns false if @stop is closed first.
+func wait(clock Clock, stop <-chan struct{}, d time.Duration) bool {
+	select {
+	case <-stop:
+		return false
+	case <-clockOrSystem(clock).After(d):
+		return true
+	}
+}
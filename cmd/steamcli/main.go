@@ -0,0 +1,337 @@
+// Command steamcli runs common operations from the command line, it reads the
+// credentials from the same environment variables as the examples:
+// steamAccount, steamPassword, steamSharedSecret, steamIdentitySecret and steamAPIKey.
+//
+//	steamcli [-state file] <command> [arguments]
+//
+// Commands:
+//
+//	login                                      check the credentials
+//	inventory [-steamid id] <appid> <contextid> dump an inventory as NDJSON
+//	price [-currency id] [-country cc] <appid> <market hash name>
+//	sell <appid> <contextid> <assetid> <price in cents> [amount]
+//	buy-order [-currency id] <appid> <market hash name> <price total> <quantity>
+//	offer-send [-message text] <trade url> <appid> <contextid> <assetid>...
+//	offer-accept <offer id>
+//	confirmations [-answer allow|cancel] [confirmation id...]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/multicus/steam"
+)
+
+var (
+	session  *steam.Session
+	timeDiff time.Duration
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: steamcli [-state file] <login|inventory|price|sell|buy-order|offer-send|offer-accept|confirmations> [arguments]")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "steamcli:", err)
+	os.Exit(1)
+}
+
+func parseUint(s string) uint64 {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		fatal(fmt.Errorf("invalid number %q", s))
+	}
+
+	return v
+}
+
+// login restores the session from @stateFile when it's still logged in,
+// logs in with the credentials otherwise and saves the state.
+func login(stateFile string) error {
+	timeTip, err := steam.GetTimeTip()
+	if err != nil {
+		return err
+	}
+	timeDiff = time.Duration(timeTip.Time-time.Now().Unix()) * time.Second
+
+	session = steam.NewSession(&http.Client{}, os.Getenv("steamAPIKey"))
+	if len(stateFile) != 0 {
+		if data, err := ioutil.ReadFile(stateFile); err == nil {
+			state := &steam.SessionState{}
+			if err = json.Unmarshal(data, state); err != nil {
+				return err
+			}
+
+			if err = session.RestoreState(state); err != nil {
+				return err
+			}
+
+			if _, ok, err := session.IsLoggedIn(context.Background()); err == nil && ok {
+				return nil
+			}
+		}
+	}
+
+	if err := session.Login(os.Getenv("steamAccount"), os.Getenv("steamPassword"), os.Getenv("steamSharedSecret"), timeDiff); err != nil {
+		return err
+	}
+
+	if len(stateFile) != 0 {
+		data, err := json.Marshal(session.ExportState())
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(stateFile, data, 0600)
+	}
+
+	return nil
+}
+
+func main() {
+	stateFile := flag.String("state", "", "file to keep the session in between runs")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+	}
+
+	commands := map[string]func([]string) error{
+		"login":         loginCommand,
+		"inventory":     inventoryCommand,
+		"price":         priceCommand,
+		"sell":          sellCommand,
+		"buy-order":     buyOrderCommand,
+		"offer-send":    offerSendCommand,
+		"offer-accept":  offerAcceptCommand,
+		"confirmations": confirmationsCommand,
+	}
+
+	command, ok := commands[flag.Arg(0)]
+	if !ok {
+		usage()
+	}
+
+	if err := login(*stateFile); err != nil {
+		fatal(err)
+	}
+
+	if err := command(flag.Args()[1:]); err != nil {
+		fatal(err)
+	}
+}
+
+func newFlagSet(name string, minArgs int, args []string, define func(*flag.FlagSet)) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	if define != nil {
+		define(fs)
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < minArgs {
+		fmt.Fprintf(os.Stderr, "steamcli %s: expected at least %d arguments\n", name, minArgs)
+		os.Exit(2)
+	}
+
+	return fs
+}
+
+func loginCommand(args []string) error {
+	sid, ok, err := session.IsLoggedIn(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("not logged in")
+	}
+
+	fmt.Println(sid.ToString())
+	return nil
+}
+
+func inventoryCommand(args []string) error {
+	var steamID string
+	fs := newFlagSet("inventory", 2, args, func(fs *flag.FlagSet) {
+		fs.StringVar(&steamID, "steamid", "", "inventory owner, us by default")
+	})
+
+	sid := session.GetSteamID()
+	if len(steamID) != 0 {
+		sid = steam.SteamID(parseUint(steamID))
+	}
+
+	items, err := session.GetInventory(sid, parseUint(fs.Arg(0)), parseUint(fs.Arg(1)), false)
+	if err != nil {
+		return err
+	}
+
+	return steam.WriteNDJSON(os.Stdout, items)
+}
+
+func priceCommand(args []string) error {
+	var currency, country string
+	fs := newFlagSet("price", 2, args, func(fs *flag.FlagSet) {
+		fs.StringVar(&currency, "currency", steam.CurrencyUSD, "currency id")
+		fs.StringVar(&country, "country", "US", "country code")
+	})
+
+	overview, err := session.GetMarketItemPriceOverview(parseUint(fs.Arg(0)), country, currency, fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	if !overview.Success {
+		return steam.ErrCannotLoadPrices
+	}
+
+	fmt.Printf("lowest: %s median: %s volume: %s\n", overview.LowestPrice, overview.MedianPrice, overview.Volume)
+	return nil
+}
+
+func findItem(appID, contextID, assetID uint64) (*steam.InventoryItem, error) {
+	items, err := session.GetInventory(session.GetSteamID(), appID, contextID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		if items[i].AssetID == assetID {
+			return &items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("asset %d not found", assetID)
+}
+
+func sellCommand(args []string) error {
+	fs := newFlagSet("sell", 4, args, nil)
+	item, err := findItem(parseUint(fs.Arg(0)), parseUint(fs.Arg(1)), parseUint(fs.Arg(2)))
+	if err != nil {
+		return err
+	}
+
+	amount := uint64(1)
+	if fs.NArg() > 4 {
+		amount = parseUint(fs.Arg(4))
+	}
+
+	resp, err := session.SellItem(item, amount, parseUint(fs.Arg(3)))
+	if err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		return errors.New("listing failed")
+	}
+
+	if resp.RequiresConfirmation != 0 {
+		fmt.Println("listed, needs confirmation")
+	} else {
+		fmt.Println("listed")
+	}
+
+	return nil
+}
+
+func buyOrderCommand(args []string) error {
+	var currency string
+	fs := newFlagSet("buy-order", 4, args, func(fs *flag.FlagSet) {
+		fs.StringVar(&currency, "currency", steam.CurrencyUSD, "currency id")
+	})
+
+	priceTotal, err := strconv.ParseFloat(fs.Arg(2), 64)
+	if err != nil {
+		return err
+	}
+
+	results := session.PlaceBuyOrders([]*steam.BuyOrderRequest{{
+		AppID:          parseUint(fs.Arg(0)),
+		MarketHashName: fs.Arg(1),
+		PriceTotal:     priceTotal,
+		Quantity:       parseUint(fs.Arg(3)),
+		CurrencyID:     currency,
+	}})
+	if results[0].Err != nil {
+		return results[0].Err
+	}
+
+	fmt.Println(results[0].Request.OrderID)
+	return nil
+}
+
+func offerSendCommand(args []string) error {
+	var message string
+	fs := newFlagSet("offer-send", 4, args, func(fs *flag.FlagSet) {
+		fs.StringVar(&message, "message", "", "offer message")
+	})
+
+	appID, contextID := parseUint(fs.Arg(1)), parseUint(fs.Arg(2))
+	offer := &steam.TradeOffer{Message: message}
+	for _, assetID := range fs.Args()[3:] {
+		offer.SendItems = append(offer.SendItems, &steam.EconItem{
+			AssetID:   parseUint(assetID),
+			AppID:     uint32(appID),
+			ContextID: contextID,
+			Amount:    1,
+		})
+	}
+
+	if err := session.SendTradeOfferURL(offer, fs.Arg(0)); err != nil {
+		return err
+	}
+
+	fmt.Println(offer.ID)
+	return nil
+}
+
+func offerAcceptCommand(args []string) error {
+	fs := newFlagSet("offer-accept", 1, args, nil)
+	return session.AcceptTradeOffer(parseUint(fs.Arg(0)))
+}
+
+func confirmationsCommand(args []string) error {
+	var answer string
+	fs := newFlagSet("confirmations", 0, args, func(fs *flag.FlagSet) {
+		fs.StringVar(&answer, "answer", "", "allow or cancel the confirmations given, or all of them if none is given")
+	})
+
+	identitySecret := os.Getenv("steamIdentitySecret")
+	confirmations, err := session.GetConfirmations(identitySecret, time.Now().Add(timeDiff).Unix())
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[uint64]bool)
+	for _, id := range fs.Args() {
+		ids[parseUint(id)] = true
+	}
+
+	for _, c := range confirmations {
+		if len(ids) != 0 && !ids[c.ID] {
+			continue
+		}
+
+		fmt.Printf("%d\t%s\t%s\t%s\n", c.ID, c.Title, c.Receiving, c.Since)
+		if len(answer) == 0 {
+			continue
+		}
+
+		if err = session.AnswerConfirmation(c, identitySecret, answer, time.Now().Add(timeDiff).Unix()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,348 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Who can comment on the profile.
+const (
+	CommentPermissionFriendsOnly = iota
+	CommentPermissionPublic
+	CommentPermissionPrivate
+)
+
+// Maximum number of comments asked for per page.
+const commentsPageSize = 50
+
+var (
+	ErrCannotFindPrivacySettings = errors.New("unable to find privacy settings")
+	ErrCannotSetPrivacy          = errors.New("unable to change privacy settings")
+	ErrCannotLoadComments        = errors.New("unable to load comments at this time")
+	ErrCannotDeleteComment       = errors.New("unable to delete comment")
+	ErrCannotPostComment         = errors.New("unable to post comment")
+)
+
+// ProfilePrivacySettings values are 1 for private, 2 for friends only and 3 for public.
+type ProfilePrivacySettings struct {
+	PrivacyProfile        int `json:"PrivacyProfile"`
+	PrivacyInventory      int `json:"PrivacyInventory"`
+	PrivacyInventoryGifts int `json:"PrivacyInventoryGifts"`
+	PrivacyOwnedGames     int `json:"PrivacyOwnedGames"`
+	PrivacyPlaytime       int `json:"PrivacyPlaytime"`
+	PrivacyFriendsList    int `json:"PrivacyFriendsList"`
+}
+
+type ProfilePrivacy struct {
+	Settings          ProfilePrivacySettings `json:"PrivacySettings"`
+	CommentPermission int                    `json:"eCommentPermission"`
+}
+
+type ProfileComment struct {
+	ID     uint64
+	Author SteamID
+	Text   string
+	Time   int64
+}
+
+func (session *Session) profileBaseURL() string {
+	return "https://steamcommunity.com/profiles/" + session.oauth.SteamID.ToString()
+}
+
+// GetProfilePrivacy reads the privacy settings, comment permission included, from
+// the profile settings page.
+func (session *Session) GetProfilePrivacy() (*ProfilePrivacy, error) {
+	resp, err := session.client.Get(session.profileBaseURL() + "/edit/settings")
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	config, ok := doc.Find("#profile_edit_config").Attr("data-profile-edit")
+	if !ok {
+		return nil, ErrCannotFindPrivacySettings
+	}
+
+	type Config struct {
+		Privacy *ProfilePrivacy `json:"Privacy"`
+	}
+
+	var c Config
+	if err = json.Unmarshal([]byte(config), &c); err != nil {
+		return nil, err
+	}
+
+	if c.Privacy == nil {
+		return nil, ErrCannotFindPrivacySettings
+	}
+
+	return c.Privacy, nil
+}
+
+func (session *Session) SetProfilePrivacySettings(privacy *ProfilePrivacy) error {
+	settings, err := json.Marshal(&privacy.Settings)
+	if err != nil {
+		return err
+	}
+
+	resp, err := session.client.PostForm(session.profileBaseURL()+"/ajaxsetprivacy/", url.Values{
+		"sessionid":          {session.sessionID},
+		"Privacy":            {string(settings)},
+		"eCommentPermission": {strconv.Itoa(privacy.CommentPermission)},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int `json:"success"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Success != 1 {
+		return ErrCannotSetPrivacy
+	}
+
+	return nil
+}
+
+// SetCommentPermission changes who can comment (CommentPermission*), leaving the
+// other privacy settings as they are.
+func (session *Session) SetCommentPermission(permission int) error {
+	privacy, err := session.GetProfilePrivacy()
+	if err != nil {
+		return err
+	}
+
+	privacy.CommentPermission = permission
+	return session.SetProfilePrivacySettings(privacy)
+}
+
+func (session *Session) commentRequest(action string, values url.Values) (*goquery.Document, int, error) {
+	return session.threadCommentRequest("Profile", action, session.oauth.SteamID.ToString(), "-1", values)
+}
+
+// threadCommentRequest acts on the comment thread of type @thread (e.g. "Profile")
+// of @owner, @gid is the ID of the commented thing for the threads that need one.
+func (session *Session) threadCommentRequest(thread, action, owner, gid string, values url.Values) (*goquery.Document, int, error) {
+	values.Set("sessionid", session.sessionID)
+	values.Set("feature2", "-1")
+
+	resp, err := session.client.PostForm(fmt.Sprintf(
+		"https://steamcommunity.com/comment/%s/%s/%s/%s/",
+		thread,
+		action,
+		owner,
+		gid,
+	), values)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success    bool   `json:"success"`
+		TotalCount int    `json:"total_count"`
+		HTML       string `json:"comments_html"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, err
+	}
+
+	if !response.Success {
+		return nil, 0, ErrCannotLoadComments
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(response.HTML))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return doc, response.TotalCount, nil
+}
+
+// GetProfileComments returns the comments on our profile, newest first, along with
+// how many there are in total.
+func (session *Session) GetProfileComments(start, count int) ([]*ProfileComment, int, error) {
+	doc, total, err := session.commentRequest("render", url.Values{
+		"start": {strconv.Itoa(start)},
+		"count": {strconv.Itoa(count)},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return parseComments(doc), total, nil
+}
+
+func parseComments(doc *goquery.Document) []*ProfileComment {
+	comments := []*ProfileComment{}
+	doc.Find(".commentthread_comment").Each(func(i int, s *goquery.Selection) {
+		id, err := strconv.ParseUint(strings.TrimPrefix(s.AttrOr("id", ""), "comment_"), 10, 64)
+		if err != nil {
+			return
+		}
+
+		comment := &ProfileComment{
+			ID:   id,
+			Text: strings.TrimSpace(s.Find(".commentthread_comment_text").Text()),
+		}
+
+		if accountID, err := strconv.ParseUint(s.Find(".commentthread_author_link").AttrOr("data-miniprofile", ""), 10, 32); err == nil {
+			comment.Author.ParseDefaults(uint32(accountID))
+		}
+
+		comment.Time, _ = strconv.ParseInt(s.Find(".commentthread_comment_timestamp").AttrOr("data-timestamp", ""), 10, 64)
+		comments = append(comments, comment)
+	})
+
+	return comments
+}
+
+func (session *Session) DeleteProfileComment(commentID uint64) error {
+	_, _, err := session.commentRequest("delete", url.Values{
+		"gidcomment": {strconv.FormatUint(commentID, 10)},
+		"start":      {"0"},
+		"count":      {"1"},
+	})
+	if err == ErrCannotLoadComments {
+		return ErrCannotDeleteComment
+	}
+
+	return err
+}
+
+// CommentContains matches comments containing any of @texts (case insensitive),
+// e.g. the domains of known scam links.
+func CommentContains(texts ...string) func(*ProfileComment) bool {
+	return func(comment *ProfileComment) bool {
+		text := strings.ToLower(comment.Text)
+		for _, t := range texts {
+			if strings.Contains(text, strings.ToLower(t)) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// DeleteProfileComments goes over every comment of our profile and deletes the ones
+// @match returns true for, it returns how many were deleted.
+func (session *Session) DeleteProfileComments(match func(*ProfileComment) bool) (int, error) {
+	deleted := 0
+	for start := 0; ; {
+		comments, total, err := session.GetProfileComments(start, commentsPageSize)
+		if err != nil {
+			return deleted, err
+		}
+
+		if len(comments) == 0 {
+			return deleted, nil
+		}
+
+		kept := 0
+		for _, comment := range comments {
+			if !match(comment) {
+				kept++
+				continue
+			}
+
+			if err = session.DeleteProfileComment(comment.ID); err != nil {
+				return deleted, err
+			}
+			deleted++
+			total--
+		}
+
+		// Deleted comments shift the following ones down.
+		if start += kept; start >= total {
+			return deleted, nil
+		}
+	}
+}
+
+// publishedFileCommentRequest acts on the comments of the published file @id
+// made by @creator (see PublishedFileDetails.Creator).
+func (session *Session) publishedFileCommentRequest(action string, creator SteamID, id uint64, values url.Values) (*goquery.Document, int, error) {
+	return session.threadCommentRequest("PublishedFile_Public", action, creator.ToString(), strconv.FormatUint(id, 10), values)
+}
+
+// GetPublishedFileComments returns the comments on a published file, newest first,
+// along with how many there are in total.
+func (session *Session) GetPublishedFileComments(creator SteamID, id uint64, start, count int) ([]*ProfileComment, int, error) {
+	doc, total, err := session.publishedFileCommentRequest("render", creator, id, url.Values{
+		"start": {strconv.Itoa(start)},
+		"count": {strconv.Itoa(count)},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return parseComments(doc), total, nil
+}
+
+func (session *Session) PostPublishedFileComment(creator SteamID, id uint64, text string) error {
+	_, _, err := session.publishedFileCommentRequest("post", creator, id, url.Values{
+		"comment": {text},
+		"count":   {"1"},
+	})
+	if err == ErrCannotLoadComments {
+		return ErrCannotPostComment
+	}
+
+	return err
+}
+
+func (session *Session) DeletePublishedFileComment(creator SteamID, id, commentID uint64) error {
+	_, _, err := session.publishedFileCommentRequest("delete", creator, id, url.Values{
+		"gidcomment": {strconv.FormatUint(commentID, 10)},
+		"start":      {"0"},
+		"count":      {"1"},
+	})
+	if err == ErrCannotLoadComments {
+		return ErrCannotDeleteComment
+	}
+
+	return err
+}
@@ -0,0 +1,138 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var ErrCannotSearchCommunity = errors.New("unable to search the community at this time")
+
+// CommunityUser is a user search result, CustomURL is set for the users with
+// a vanity URL.
+type CommunityUser struct {
+	SteamID   SteamID
+	Name      string
+	CustomURL string
+	AvatarURL string
+}
+
+// CommunityGroup is a group search result, GroupID is only known for the groups
+// without a vanity URL, see URL otherwise.
+type CommunityGroup struct {
+	GroupID   SteamID
+	Name      string
+	URL       string
+	Members   uint32
+	AvatarURL string
+}
+
+// searchCommunity returns the results page of the community search.
+func (session *Session) searchCommunity(filter, query string, page int) (*goquery.Document, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/search/SearchCommunityAjax?" + url.Values{
+		"text":         {query},
+		"filter":       {filter},
+		"sessionid":    {session.sessionID},
+		"steamid_user": {"false"},
+		"page":         {strconv.Itoa(page)},
+	}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int    `json:"success"`
+		HTML    string `json:"html"`
+	}
+
+	var response Response
+	if err = session.decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Success != 1 {
+		return nil, ErrCannotSearchCommunity
+	}
+
+	return goquery.NewDocumentFromReader(strings.NewReader(response.HTML))
+}
+
+// SearchUsers returns the users matching @query, @page starts at 1 (20 per page).
+func (session *Session) SearchUsers(query string, page int) ([]*CommunityUser, error) {
+	doc, err := session.searchCommunity("users", query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []*CommunityUser{}
+	doc.Find(".search_row").Each(func(i int, row *goquery.Selection) {
+		user := &CommunityUser{
+			AvatarURL: row.Find(".avatarMedium img").AttrOr("src", ""),
+		}
+
+		if accountID, err := strconv.ParseUint(row.AttrOr("data-miniprofile", ""), 10, 32); err == nil {
+			user.SteamID.ParseDefaults(uint32(accountID))
+		}
+
+		link := row.Find(".searchPersonaName")
+		user.Name = strings.TrimSpace(link.Text())
+		href := link.AttrOr("href", "")
+		if i := strings.Index(href, "/id/"); i != -1 {
+			user.CustomURL = strings.Trim(href[i+len("/id/"):], "/")
+		} else if i := strings.Index(href, "/profiles/"); i != -1 && user.SteamID == 0 {
+			id, _ := strconv.ParseUint(strings.Trim(href[i+len("/profiles/"):], "/"), 10, 64)
+			user.SteamID = SteamID(id)
+		}
+
+		users = append(users, user)
+	})
+
+	return users, nil
+}
+
+// SearchGroups returns the groups matching @query, @page starts at 1 (20 per page).
+func (session *Session) SearchGroups(query string, page int) ([]*CommunityGroup, error) {
+	doc, err := session.searchCommunity("groups", query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*CommunityGroup{}
+	doc.Find(".search_row").Each(func(i int, row *goquery.Selection) {
+		link := row.Find(".searchPersonaName")
+		group := &CommunityGroup{
+			Name:      strings.TrimSpace(link.Text()),
+			URL:       link.AttrOr("href", ""),
+			AvatarURL: row.Find(".avatarMedium img").AttrOr("src", ""),
+		}
+
+		if i := strings.Index(group.URL, "/gid/"); i != -1 {
+			id, _ := strconv.ParseUint(strings.Trim(group.URL[i+len("/gid/"):], "/"), 10, 64)
+			group.GroupID = SteamID(id)
+		}
+
+		// e.g. "1,234 Members"
+		members := strings.Fields(row.Find(".search_match_info").Text())
+		if len(members) != 0 {
+			count, _ := strconv.ParseUint(strings.Replace(members[0], ",", "", -1), 10, 32)
+			group.Members = uint32(count)
+		}
+
+		groups = append(groups, group)
+	})
+
+	return groups, nil
+}
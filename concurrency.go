@@ -0,0 +1,83 @@
+package steam
+
+import (
+	"net/http"
+	"sync"
+)
+
+// concurrencyLimits caps the requests in flight, a request is in flight until
+// its response body is closed.
+type concurrencyLimits struct {
+	all     chan struct{}
+	perHost int
+	hosts   map[string]chan struct{}
+	mutex   sync.Mutex
+}
+
+// SetConcurrency caps the requests of the session in flight at the same time to
+// @max overall and @perHost per host, 0 means no cap.  Requests waiting for a
+// slot don't hold the rate limiter.
+func (session *Session) SetConcurrency(max, perHost int) {
+	if max == 0 && perHost == 0 {
+		session.concurrency = nil
+		return
+	}
+
+	limits := &concurrencyLimits{
+		perHost: perHost,
+		hosts:   make(map[string]chan struct{}),
+	}
+	if max > 0 {
+		limits.all = make(chan struct{}, max)
+	}
+	session.concurrency = limits
+}
+
+func (limits *concurrencyLimits) host(host string) chan struct{} {
+	if limits.perHost <= 0 {
+		return nil
+	}
+
+	limits.mutex.Lock()
+	defer limits.mutex.Unlock()
+
+	sem, ok := limits.hosts[host]
+	if !ok {
+		sem = make(chan struct{}, limits.perHost)
+		limits.hosts[host] = sem
+	}
+
+	return sem
+}
+
+// acquire blocks until @req can be sent and returns the function releasing its slots.
+func (limits *concurrencyLimits) acquire(req *http.Request) (func(), error) {
+	host := limits.host(req.URL.Host)
+	if host != nil {
+		select {
+		case host <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	if limits.all != nil {
+		select {
+		case limits.all <- struct{}{}:
+		case <-req.Context().Done():
+			if host != nil {
+				<-host
+			}
+			return nil, req.Context().Err()
+		}
+	}
+
+	return func() {
+		if limits.all != nil {
+			<-limits.all
+		}
+		if host != nil {
+			<-host
+		}
+	}, nil
+}
@@ -0,0 +1,163 @@
+package steam
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Responses bigger than this are not kept by the validator cache.
+const maxValidatedBody = 4 << 20
+
+// ValidatedResponse is a response with its cache validators, kept to answer the
+// requests Steam replies 304 Not Modified to.
+type ValidatedResponse struct {
+	ETag         string
+	LastModified string
+	StatusCode   int
+	Header       http.Header
+	Body         []byte
+}
+
+// ValidatorCache keeps the responses having an ETag or Last-Modified, by URL.
+// It must be safe for concurrent use.
+type ValidatorCache interface {
+	Get(url string) (*ValidatedResponse, bool)
+	Put(url string, resp *ValidatedResponse)
+}
+
+type memoryValidatorCache struct {
+	max     int
+	order   *list.List // Most recently used first
+	entries map[string]*list.Element
+	mutex   sync.Mutex
+}
+
+type memoryValidatorEntry struct {
+	url  string
+	resp *ValidatedResponse
+}
+
+// NewMemoryValidatorCache keeps up to @max responses, the least recently used
+// ones are dropped first.
+func NewMemoryValidatorCache(max int) ValidatorCache {
+	return &memoryValidatorCache{
+		max:     max,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (cache *memoryValidatorCache) Get(url string) (*ValidatedResponse, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	elem, ok := cache.entries[url]
+	if !ok {
+		return nil, false
+	}
+
+	cache.order.MoveToFront(elem)
+	return elem.Value.(*memoryValidatorEntry).resp, true
+}
+
+func (cache *memoryValidatorCache) Put(url string, resp *ValidatedResponse) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if elem, ok := cache.entries[url]; ok {
+		elem.Value.(*memoryValidatorEntry).resp = resp
+		cache.order.MoveToFront(elem)
+		return
+	}
+
+	cache.entries[url] = cache.order.PushFront(&memoryValidatorEntry{url, resp})
+	for cache.order.Len() > cache.max {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*memoryValidatorEntry).url)
+	}
+}
+
+// SetValidatorCache makes GET requests conditional (If-None-Match, If-Modified-Since)
+// when @cache has a previous response for the URL, a 304 from Steam is then
+// answered from the cache as the original response.  Only the responses with an
+// ETag or Last-Modified are kept, which Steam mostly sends for images and a few
+// API calls, the other requests are unchanged.  nil disables it.
+func (session *Session) SetValidatorCache(cache ValidatorCache) {
+	session.validators = cache
+}
+
+func (cached *ValidatedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(cached.StatusCode) + " " + http.StatusText(cached.StatusCode),
+		StatusCode:    cached.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
+
+func (transport *sessionTransport) conditional(req *http.Request, cache ValidatorCache) (*http.Response, error) {
+	key := req.URL.String()
+	cached, ok := cache.Get(key)
+	if ok && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+		req = req.Clone(req.Context())
+		if len(cached.ETag) != 0 {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if len(cached.LastModified) != 0 {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := transport.forward(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		resp.Body.Close()
+		return cached.response(resp.Request), nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (len(etag) == 0 && len(lastModified) == 0) {
+		return resp, nil
+	}
+
+	// Keep the body only if it's not too big, otherwise pass it on as it is.
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxValidatedBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	if len(body) > maxValidatedBody {
+		rest := resp.Body
+		resp.Body = &closeHookBody{
+			ReadCloser: ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), rest)),
+			onClose:    func() { rest.Close() },
+		}
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	validated := &ValidatedResponse{
+		ETag:         etag,
+		LastModified: lastModified,
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header,
+		Body:         body,
+	}
+	cache.Put(key, validated)
+	return validated.response(resp.Request), nil
+}
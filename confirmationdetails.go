@@ -0,0 +1,128 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var ErrCannotLoadConfirmationDetails = errors.New("unable to load confirmation details")
+
+// ConfirmationItem is an item of a trade confirmation, Ours if we're giving it.
+// Only the class is in the details, see ConfirmationDetails.Offer for the names.
+type ConfirmationItem struct {
+	Ours       bool
+	AppID      uint32
+	ClassID    uint64
+	InstanceID uint64
+}
+
+// ConfirmationDetails is what the details page of a confirmation shows, Items
+// for trades and the prices (as shown) for market listings.
+type ConfirmationDetails struct {
+	Confirmation *Confirmation
+	Items        []*ConfirmationItem
+	PartnerName  string
+	BuyerPays    string
+	YouReceive   string
+	HTML         string
+}
+
+// parseConfirmationItems reads the data-economy-item of the items, which is
+// "classinfo/<appid>/<classid>/<instanceid>".
+func parseConfirmationItems(doc *goquery.Document, ours bool, selector string) []*ConfirmationItem {
+	items := []*ConfirmationItem{}
+	doc.Find(selector + " .trade_item").Each(func(i int, s *goquery.Selection) {
+		parts := strings.Split(s.AttrOr("data-economy-item", ""), "/")
+		if len(parts) < 3 || parts[0] != "classinfo" {
+			return
+		}
+
+		item := &ConfirmationItem{Ours: ours}
+		appID, _ := strconv.ParseUint(parts[1], 10, 32)
+		item.AppID = uint32(appID)
+		item.ClassID, _ = strconv.ParseUint(parts[2], 10, 64)
+		if len(parts) > 3 {
+			item.InstanceID, _ = strconv.ParseUint(parts[3], 10, 64)
+		}
+
+		items = append(items, item)
+	})
+
+	return items
+}
+
+// GetConfirmationDetails fetches the details page of @confirmation.
+func (session *Session) GetConfirmationDetails(confirmation *Confirmation, identitySecret string, current int64) (*ConfirmationDetails, error) {
+	tag := "details" + strconv.FormatUint(confirmation.ID, 10)
+	key, err := GenerateConfirmationCode(identitySecret, tag, current)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := session.execConfirmationRequest("details/"+strconv.FormatUint(confirmation.ID, 10)+"?", key, tag, current, nil)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	type Response struct {
+		Success bool   `json:"success"`
+		HTML    string `json:"html"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, ErrCannotLoadConfirmationDetails
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(response.HTML))
+	if err != nil {
+		return nil, err
+	}
+
+	details := &ConfirmationDetails{
+		Confirmation: confirmation,
+		HTML:         response.HTML,
+		PartnerName:  strings.TrimSpace(doc.Find(".tradeoffer_items.secondary .tradeoffer_items_header").Text()),
+	}
+
+	// The primary items are the ones of whoever made the offer.
+	primaryOurs := doc.Find(".tradeoffer_items.primary .tradeoffer_items_header").Text() == "You offered:"
+	details.Items = append(parseConfirmationItems(doc, primaryOurs, ".tradeoffer_items.primary"),
+		parseConfirmationItems(doc, !primaryOurs, ".tradeoffer_items.secondary")...)
+
+	doc.Find(".mobileconf_listing_prices").Contents().Each(func(i int, s *goquery.Selection) {
+		text := strings.TrimSpace(s.Text())
+		if i := strings.Index(text, ":"); i != -1 {
+			switch label, value := text[:i], strings.TrimSpace(text[i+1:]); label {
+			case "Buyer pays":
+				details.BuyerPays = value
+			case "You receive":
+				details.YouReceive = value
+			}
+		}
+	})
+
+	return details, nil
+}
+
+// Offer fetches the trade offer of a trade confirmation with the descriptions
+// of the items (names, tags...) which the details page doesn't have.
+func (details *ConfirmationDetails) Offer(session *Session) (*TradeOffer, error) {
+	if details.Confirmation.Type != ConfirmationTypeTrade || details.Confirmation.OfferID == 0 {
+		return nil, ErrCannotLoadConfirmationDetails
+	}
+
+	return session.GetTradeOfferWithDescriptions(details.Confirmation.OfferID)
+}
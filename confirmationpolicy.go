@@ -0,0 +1,354 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	ConfirmationDecisionNone = iota // Leave it pending
+	ConfirmationDecisionAccept
+	ConfirmationDecisionDeny
+)
+
+var ErrCannotAppraise = errors.New("unable to appraise item")
+
+var confirmationDecisionNames = map[int]string{
+	ConfirmationDecisionNone:   "none",
+	ConfirmationDecisionAccept: "accept",
+	ConfirmationDecisionDeny:   "deny",
+}
+
+// ConfirmationCandidate is what the rules decide on, Offer is only set for
+// trade confirmations.
+type ConfirmationCandidate struct {
+	Confirmation *Confirmation
+	Details      *ConfirmationDetails
+	Offer        *TradeOffer
+}
+
+// ConfirmationRule returns one of the ConfirmationDecision* for @candidate,
+// ConfirmationDecisionNone if it doesn't apply.
+type ConfirmationRule struct {
+	Name   string
+	Decide func(candidate *ConfirmationCandidate) (int, error)
+}
+
+// ItemAppraiser values an item (all of its Amount), in cents.
+type ItemAppraiser interface {
+	Appraise(item *EconItem) (uint64, error)
+}
+
+// MarketAppraiser appraises items at their median market price, or the lowest
+// listing if there were no sales.
+type MarketAppraiser struct {
+	Session    *Session
+	CurrencyID string
+}
+
+func (appraiser *MarketAppraiser) Appraise(item *EconItem) (uint64, error) {
+	if item.Desc == nil || len(item.Desc.MarketHashName) == 0 {
+		return 0, ErrCannotAppraise
+	}
+
+	overview, err := appraiser.Session.GetMarketItemPriceOverview(uint64(item.AppID), "", appraiser.CurrencyID, item.Desc.MarketHashName)
+	if err != nil {
+		return 0, err
+	}
+
+	price := overview.ParsedMedian
+	if price == 0 {
+		price = overview.ParsedLowest
+	}
+
+	if price == 0 {
+		return 0, ErrCannotAppraise
+	}
+
+	amount := item.Amount
+	if amount == 0 {
+		amount = 1
+	}
+
+	return price * amount, nil
+}
+
+// AcceptConfirmationType accepts every confirmation of type @confirmationType
+// (ConfirmationType*), e.g. our own market listings.
+func AcceptConfirmationType(confirmationType int) ConfirmationRule {
+	return ConfirmationRule{
+		Name: "type " + strconv.Itoa(confirmationType),
+		Decide: func(candidate *ConfirmationCandidate) (int, error) {
+			if candidate.Confirmation.Type == confirmationType {
+				return ConfirmationDecisionAccept, nil
+			}
+
+			return ConfirmationDecisionNone, nil
+		},
+	}
+}
+
+func partnerRule(name string, decision int, partners []SteamID) ConfirmationRule {
+	accounts := make(map[uint32]bool, len(partners))
+	for _, partner := range partners {
+		accounts[partner.GetAccountID()] = true
+	}
+
+	return ConfirmationRule{
+		Name: name,
+		Decide: func(candidate *ConfirmationCandidate) (int, error) {
+			if candidate.Offer != nil && accounts[candidate.Offer.Partner] {
+				return decision, nil
+			}
+
+			return ConfirmationDecisionNone, nil
+		},
+	}
+}
+
+// AcceptPartners accepts trades with any of @partners.
+func AcceptPartners(partners ...SteamID) ConfirmationRule {
+	return partnerRule("partner allowlist", ConfirmationDecisionAccept, partners)
+}
+
+// DenyPartners denies trades with any of @partners.
+func DenyPartners(partners ...SteamID) ConfirmationRule {
+	return partnerRule("partner denylist", ConfirmationDecisionDeny, partners)
+}
+
+// DenyItems denies trades giving away any item @match returns true for, items
+// without descriptions are denied too since they cannot be checked.
+func DenyItems(name string, match func(*EconItemDesc) bool) ConfirmationRule {
+	return ConfirmationRule{
+		Name: name,
+		Decide: func(candidate *ConfirmationCandidate) (int, error) {
+			if candidate.Offer == nil {
+				return ConfirmationDecisionNone, nil
+			}
+
+			for _, item := range candidate.Offer.SendItems {
+				if item.Desc == nil || match(item.Desc) {
+					return ConfirmationDecisionDeny, nil
+				}
+			}
+
+			return ConfirmationDecisionNone, nil
+		},
+	}
+}
+
+// AllowItems denies trades giving away anything but the items of @marketHashNames.
+func AllowItems(marketHashNames ...string) ConfirmationRule {
+	allowed := make(map[string]bool, len(marketHashNames))
+	for _, name := range marketHashNames {
+		allowed[name] = true
+	}
+
+	return DenyItems("item allowlist", func(desc *EconItemDesc) bool {
+		return !allowed[desc.MarketHashName]
+	})
+}
+
+// MaxGivenValue denies trades where the items we give are worth more than
+// @max (in cents) according to @appraiser, or cannot be appraised.
+func MaxGivenValue(appraiser ItemAppraiser, max uint64) ConfirmationRule {
+	return ConfirmationRule{
+		Name: "max value",
+		Decide: func(candidate *ConfirmationCandidate) (int, error) {
+			if candidate.Offer == nil {
+				return ConfirmationDecisionNone, nil
+			}
+
+			total := uint64(0)
+			for _, item := range candidate.Offer.SendItems {
+				value, err := appraiser.Appraise(item)
+				if err != nil {
+					return ConfirmationDecisionDeny, err
+				}
+
+				total += value
+			}
+
+			if total > max {
+				return ConfirmationDecisionDeny, nil
+			}
+
+			return ConfirmationDecisionNone, nil
+		},
+	}
+}
+
+// ConfirmationAudit is an entry of the audit log, Answered is false in dry-run
+// mode or when the decision was to leave it pending.
+type ConfirmationAudit struct {
+	Time         time.Time `json:"time"`
+	Confirmation uint64    `json:"confirmation_id,string"`
+	Type         int       `json:"type"`
+	Title        string    `json:"title"`
+	OfferID      uint64    `json:"offer_id,string,omitempty"`
+	Decision     string    `json:"decision"`
+	Rules        []string  `json:"rules,omitempty"`
+	Answered     bool      `json:"answered"`
+	Error        string    `json:"error,omitempty"`
+
+	Candidate *ConfirmationCandidate `json:"-"`
+	Err       error                  `json:"-"`
+}
+
+// ConfirmationPolicy decides on pending confirmations with Rules: any deny wins,
+// otherwise any accept accepts, otherwise Default applies.
+// Errors of a rule deny the confirmation.
+type ConfirmationPolicy struct {
+	session        *Session
+	identitySecret string
+
+	Rules   []ConfirmationRule
+	Default int
+	// DryRun only audits the decisions without answering.
+	DryRun bool
+	// AuditLog gets every decision as a line of JSON, may be nil.
+	AuditLog io.Writer
+	// OnDecision is called for every decision, may be nil.
+	OnDecision func(*ConfirmationAudit)
+	Clock      Clock
+
+	mutex sync.Mutex
+}
+
+// NewConfirmationPolicy @clock may be nil for SystemClock.
+func (session *Session) NewConfirmationPolicy(identitySecret string, clock Clock, rules ...ConfirmationRule) *ConfirmationPolicy {
+	return &ConfirmationPolicy{
+		session:        session,
+		identitySecret: identitySecret,
+		Rules:          rules,
+		Default:        ConfirmationDecisionNone,
+		Clock:          clockOrSystem(clock),
+	}
+}
+
+// Decide runs the rules against @candidate, the names of the rules that made
+// the decision are returned along with it.
+func (policy *ConfirmationPolicy) Decide(candidate *ConfirmationCandidate) (int, []string, error) {
+	accepted := []string{}
+	denied := []string{}
+	var firstErr error
+
+	for _, rule := range policy.Rules {
+		decision, err := rule.Decide(candidate)
+		if err != nil {
+			decision = ConfirmationDecisionDeny
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+
+		switch decision {
+		case ConfirmationDecisionAccept:
+			accepted = append(accepted, rule.Name)
+		case ConfirmationDecisionDeny:
+			denied = append(denied, rule.Name)
+		}
+	}
+
+	if len(denied) != 0 {
+		return ConfirmationDecisionDeny, denied, firstErr
+	}
+
+	if len(accepted) != 0 {
+		return ConfirmationDecisionAccept, accepted, nil
+	}
+
+	return policy.Default, nil, nil
+}
+
+func (policy *ConfirmationPolicy) candidate(confirmation *Confirmation, current int64) (*ConfirmationCandidate, error) {
+	details, err := policy.session.GetConfirmationDetails(confirmation, policy.identitySecret, current)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := &ConfirmationCandidate{
+		Confirmation: confirmation,
+		Details:      details,
+	}
+
+	if confirmation.Type == ConfirmationTypeTrade {
+		if candidate.Offer, err = details.Offer(policy.session); err != nil {
+			return nil, err
+		}
+	}
+
+	return candidate, nil
+}
+
+func (policy *ConfirmationPolicy) audit(entry *ConfirmationAudit) {
+	if entry.Err != nil {
+		entry.Error = entry.Err.Error()
+	}
+
+	if policy.OnDecision != nil {
+		policy.OnDecision(entry)
+	}
+
+	if policy.AuditLog != nil {
+		policy.mutex.Lock()
+		// The log has nowhere to report its errors either.
+		json.NewEncoder(policy.AuditLog).Encode(entry)
+		policy.mutex.Unlock()
+	}
+}
+
+// Process decides on every pending confirmation once and answers them unless
+// DryRun is set, @current is the time for the confirmation codes.
+// Confirmations whose details or offer cannot be fetched are left pending and
+// audited with the error.
+func (policy *ConfirmationPolicy) Process(current int64) ([]*ConfirmationAudit, error) {
+	confirmations, err := policy.session.GetConfirmations(policy.identitySecret, current)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*ConfirmationAudit{}
+	for _, confirmation := range confirmations {
+		entry := &ConfirmationAudit{
+			Time:         clockOrSystem(policy.Clock).Now(),
+			Confirmation: confirmation.ID,
+			Type:         confirmation.Type,
+			Title:        confirmation.Title,
+			OfferID:      confirmation.OfferID,
+			Decision:     confirmationDecisionNames[ConfirmationDecisionNone],
+		}
+		entries = append(entries, entry)
+
+		entry.Candidate, entry.Err = policy.candidate(confirmation, current)
+		if entry.Err != nil {
+			policy.audit(entry)
+			continue
+		}
+
+		var decision int
+		decision, entry.Rules, entry.Err = policy.Decide(entry.Candidate)
+		entry.Decision = confirmationDecisionNames[decision]
+
+		if !policy.DryRun && decision != ConfirmationDecisionNone {
+			answer := "allow"
+			if decision == ConfirmationDecisionDeny {
+				answer = "cancel"
+			}
+
+			if err := policy.session.AnswerConfirmation(confirmation, policy.identitySecret, answer, current); err != nil {
+				entry.Err = err
+			} else {
+				entry.Answered = true
+			}
+		}
+
+		policy.audit(entry)
+	}
+
+	return entries, nil
+}
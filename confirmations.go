@@ -13,13 +13,20 @@ import (
 	"github.com/PuerkitoBio/goquery"
 )
 
+const (
+	ConfirmationTypeGeneric       = 1
+	ConfirmationTypeTrade         = 2
+	ConfirmationTypeMarketListing = 3
+)
+
 type Confirmation struct {
 	ID        uint64
 	Key       uint64
+	Type      int
 	Title     string
 	Receiving string
 	Since     string
-	OfferID   uint64
+	OfferID   uint64 // Or listing ID for market listings
 }
 
 var (
@@ -109,6 +116,8 @@ func (session *Session) GetConfirmations(identitySecret string, current int64) (
 				confirmation.Key, _ = strconv.ParseUint(attr.Val, 10, 64)
 			} else if attr.Key == "data-creator" {
 				confirmation.OfferID, _ = strconv.ParseUint(attr.Val, 10, 64)
+			} else if attr.Key == "data-type" {
+				confirmation.Type, _ = strconv.Atoi(attr.Val)
 			}
 		}
 
@@ -0,0 +1,178 @@
+// Package credentials keeps the secrets of accounts (password, shared and identity
+// secrets, saved session) encrypted at rest with AES-256-GCM, using a key supplied
+// by the user, and loads them into sessions.
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"time"
+
+	"github.com/multicus/steam"
+)
+
+// KeySize is the size of the vault keys, e.g. read from a KMS or derived from a
+// passphrase with a proper KDF (scrypt, argon2).
+const KeySize = 32
+
+var (
+	ErrInvalidKey = errors.New("vault key must be 32 bytes")
+	ErrCannotOpen = errors.New("unable to decrypt secrets, wrong key or corrupted data")
+	ErrNoSecret   = errors.New("secret not set")
+)
+
+// vaultFormatTag is authenticated along with the secrets, for future formats.
+var vaultFormatTag = []byte("steamvault1")
+
+// Secrets of an account, the fields of a maFile that matter plus the login.
+type Secrets struct {
+	AccountName    string              `json:"account_name"`
+	Password       string              `json:"password,omitempty"`
+	SharedSecret   string              `json:"shared_secret,omitempty"`
+	IdentitySecret string              `json:"identity_secret,omitempty"`
+	RevocationCode string              `json:"revocation_code,omitempty"`
+	DeviceID       string              `json:"device_id,omitempty"`
+	State          *steam.SessionState `json:"session,omitempty"` // What stays logged in
+}
+
+// TwoFactorCode generates the login code at @t.
+func (secrets *Secrets) TwoFactorCode(t time.Time) (string, error) {
+	if len(secrets.SharedSecret) == 0 {
+		return "", ErrNoSecret
+	}
+
+	return steam.GenerateTwoFactorCode(secrets.SharedSecret, t.Unix())
+}
+
+// ConfirmationCode generates the confirmation key for @tag at @t.
+func (secrets *Secrets) ConfirmationCode(tag string, t time.Time) (string, error) {
+	if len(secrets.IdentitySecret) == 0 {
+		return "", ErrNoSecret
+	}
+
+	return steam.GenerateConfirmationCode(secrets.IdentitySecret, tag, t.Unix())
+}
+
+// Apply restores the saved session in @session if there is one, or logs in with
+// the password otherwise, the device ID is set either way.  The session state is
+// not checked, see Session.IsLoggedIn.
+func (secrets *Secrets) Apply(session *steam.Session, timeOffset time.Duration) error {
+	if len(secrets.DeviceID) != 0 {
+		session.SetDeviceID(secrets.DeviceID)
+	}
+
+	if secrets.State != nil {
+		return session.RestoreState(secrets.State)
+	}
+
+	if len(secrets.Password) == 0 {
+		return ErrNoSecret
+	}
+
+	return session.Login(secrets.AccountName, secrets.Password, secrets.SharedSecret, timeOffset)
+}
+
+// Vault encrypts and decrypts secrets with its key.
+type Vault struct {
+	aead cipher.AEAD
+}
+
+func NewVault(key []byte) (*Vault, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Vault{aead}, nil
+}
+
+// Seal returns @secrets encrypted, prefixed by the random nonce.
+func (vault *Vault) Seal(secrets *Secrets) ([]byte, error) {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, vault.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return vault.aead.Seal(nonce, nonce, plain, vaultFormatTag), nil
+}
+
+func (vault *Vault) Open(data []byte) (*Secrets, error) {
+	size := vault.aead.NonceSize()
+	if len(data) < size {
+		return nil, ErrCannotOpen
+	}
+
+	plain, err := vault.aead.Open(nil, data[:size], data[size:], vaultFormatTag)
+	if err != nil {
+		return nil, ErrCannotOpen
+	}
+
+	secrets := &Secrets{}
+	if err = json.Unmarshal(plain, secrets); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// SaveFile writes @secrets encrypted to @path, readable by the owner only.
+func (vault *Vault) SaveFile(path string, secrets *Secrets) error {
+	data, err := vault.Seal(secrets)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func (vault *Vault) LoadFile(path string) (*Secrets, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return vault.Open(data)
+}
+
+// FromMaFile takes the secrets of a Steam Desktop Authenticator maFile, the session
+// it has is left out since it's not in the format of SessionState.
+func FromMaFile(maFile *steam.MaFile) *Secrets {
+	return &Secrets{
+		AccountName:    maFile.AccountName,
+		SharedSecret:   maFile.SharedSecret,
+		IdentitySecret: maFile.IdentitySecret,
+		RevocationCode: maFile.RevocationCode,
+		DeviceID:       maFile.DeviceID,
+	}
+}
+
+// MaFile exports the authenticator secrets as a maFile, the fields Secrets doesn't
+// have (e.g. the serial number) are left empty.
+func (secrets *Secrets) MaFile() *steam.MaFile {
+	return &steam.MaFile{
+		AccountName:    secrets.AccountName,
+		SharedSecret:   secrets.SharedSecret,
+		IdentitySecret: secrets.IdentitySecret,
+		RevocationCode: secrets.RevocationCode,
+		DeviceID:       secrets.DeviceID,
+		FullyEnrolled:  true,
+	}
+}
@@ -0,0 +1,71 @@
+package steam
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// AppliedSticker is a sticker (or charm) applied to a CS:GO item, as listed in its
+// description.  Wear is not part of descriptions, an ItemEnricher is needed for it.
+type AppliedSticker struct {
+	Slot     int
+	Name     string
+	ImageURL string
+}
+
+// parseAppliedStickers looks for the <div id="@id"> in the descriptions, which is
+// like:
+//
+//	<div id="sticker_info" ...><img src="..."><img src="..."><br>Sticker: A, B</div>
+func (desc *EconItemDesc) parseAppliedStickers(id string) []*AppliedSticker {
+	stickers := []*AppliedSticker{}
+	for _, d := range desc.Descriptions {
+		if d.Type != "html" || !strings.Contains(d.Value, id) {
+			continue
+		}
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(d.Value))
+		if err != nil {
+			continue
+		}
+
+		info := doc.Find("#" + id)
+		if info.Length() == 0 {
+			continue
+		}
+
+		text := strings.TrimSpace(info.Text())
+		if i := strings.Index(text, ":"); i != -1 {
+			text = text[i+1:]
+		}
+
+		names := strings.Split(text, ", ")
+		images := info.Find("img")
+		for i, name := range names {
+			name = strings.TrimSpace(name)
+			if len(name) == 0 {
+				continue
+			}
+
+			sticker := &AppliedSticker{Slot: i, Name: name}
+			if i < images.Length() {
+				sticker.ImageURL, _ = images.Eq(i).Attr("src")
+			}
+
+			stickers = append(stickers, sticker)
+		}
+	}
+
+	return stickers
+}
+
+// Stickers returns the stickers applied to a CS:GO item, in slot order.
+func (desc *EconItemDesc) Stickers() []*AppliedSticker {
+	return desc.parseAppliedStickers("sticker_info")
+}
+
+// Charms returns the charms attached to a CS:GO item.
+func (desc *EconItemDesc) Charms() []*AppliedSticker {
+	return desc.parseAppliedStickers("keychain_info")
+}
@@ -0,0 +1,101 @@
+package steam
+
+import (
+	"errors"
+	"strconv"
+)
+
+var ErrCannotParsePrice = errors.New("unable to parse price")
+
+// CurrencyCodes maps the Currency* IDs to ISO 4217 codes.
+var CurrencyCodes = map[string]string{
+	CurrencyUSD: "USD",
+	CurrencyGBP: "GBP",
+	CurrencyEUR: "EUR",
+	CurrencyCHF: "CHF",
+	CurrencyRUB: "RUB",
+	CurrencyPLN: "PLN",
+	CurrencyBRL: "BRL",
+	CurrencyJPY: "JPY",
+	CurrencyNOK: "NOK",
+	CurrencyIDR: "IDR",
+	CurrencyMYR: "MYR",
+	CurrencyPHP: "PHP",
+	CurrencySGD: "SGD",
+	CurrencyTHB: "THB",
+	CurrencyVND: "VND",
+	CurrencyKRW: "KRW",
+	CurrencyTRY: "TRY",
+	CurrencyUAH: "UAH",
+	CurrencyMXN: "MXN",
+	CurrencyCAD: "CAD",
+	CurrencyAUD: "AUD",
+	CurrencyNZD: "NZD",
+	CurrencyCNY: "CNY",
+	CurrencyINR: "INR",
+	CurrencyCLP: "CLP",
+	CurrencyPEN: "PEN",
+	CurrencyCOP: "COP",
+	CurrencyZAR: "ZAR",
+	CurrencyHKD: "HKD",
+	CurrencyTWD: "TWD",
+	CurrencySAR: "SAR",
+	CurrencyAED: "AED",
+	CurrencyARS: "ARS",
+	CurrencyILS: "ILS",
+	CurrencyBYN: "BYN",
+	CurrencyKZT: "KZT",
+	CurrencyKWD: "KWD",
+	CurrencyQAR: "QAR",
+	CurrencyCRC: "CRC",
+	CurrencyUYU: "UYU",
+	CurrencyRMB: "CNY",
+}
+
+// ParsePrice parses a price the way the market formats it for any currency
+// (e.g. "$1,234.56", "1.234,56€", "¥ 1,234", "12.345₫") and returns it in
+// cents, which is what Steam uses internally for every currency.
+// A separator followed by one or two digits is taken as the decimal separator,
+// any other separator is a thousands separator.
+func ParsePrice(text string) (uint64, error) {
+	digits := []byte{}
+	// Number of digits before the last separator followed by a digit, and
+	// before the separator waiting for one (symbols like "pуб." end with a dot).
+	separator, pending := -1, -1
+	for _, c := range text {
+		switch {
+		case c >= '0' && c <= '9':
+			if pending != -1 {
+				separator, pending = pending, -1
+			}
+			digits = append(digits, byte(c))
+		case c == '.' || c == ',':
+			if len(digits) != 0 {
+				pending = len(digits)
+			}
+		}
+	}
+
+	if len(digits) == 0 {
+		return 0, ErrCannotParsePrice
+	}
+
+	value, err := strconv.ParseUint(string(digits), 10, 64)
+	if err != nil {
+		return 0, ErrCannotParsePrice
+	}
+
+	fraction := -1
+	if separator != -1 {
+		fraction = len(digits) - separator
+	}
+
+	switch fraction {
+	case 1:
+		return value * 10, nil
+	case 2:
+		return value, nil
+	}
+
+	return value * 100, nil
+}
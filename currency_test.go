@@ -0,0 +1,78 @@
+package steam
+
+import "testing"
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		text  string
+		cents uint64
+	}{
+		{"$1,234.56", 123456},
+		{"$0.03", 3},
+		{"$12", 1200},
+		{"$1.5", 150},
+		{"$1,234.56 USD", 123456},
+		{"£12.34", 1234},
+		{"1.234,56€", 123456},
+		{"12,34€", 1234},
+		{"0,--€", 0},
+		{"CHF 12.34", 1234},
+		{"35,74 pуб.", 3574},
+		{"1 234,56 pуб.", 123456},
+		{"1 234 pуб.", 123400},
+		{"12,34zł", 1234},
+		{"R$ 1.234,56", 123456},
+		{"¥ 1,234", 123400},
+		{"12,34 kr", 1234},
+		{"Rp 12 345", 1234500},
+		{"RM12.34", 1234},
+		{"P12.34", 1234},
+		{"S$12.34", 1234},
+		{"฿12.34", 1234},
+		{"12.345₫", 1234500},
+		{"₩ 1,234", 123400},
+		{"12,34 TL", 1234},
+		{"1 234,56₴", 123456},
+		{"Mex$ 1,234.56", 123456},
+		{"CDN$ 12.34", 1234},
+		{"A$ 12.34", 1234},
+		{"NZ$ 12.34", 1234},
+		{"¥ 12.34", 1234},
+		{"₹ 1,234.56", 123456},
+		{"CLP$ 1.234", 123400},
+		{"S/.12.34", 1234},
+		{"COL$ 1.234,56", 123456},
+		{"R 12.34", 1234},
+		{"HK$ 12.34", 1234},
+		{"NT$ 1,234", 123400},
+		{"12.34 SR", 1234},
+		{"12.34 AED", 1234},
+		{"ARS$ 1.234,56", 123456},
+		{"₪12.34", 1234},
+		{"1 234,56₸", 123456},
+		{"12.34 KD", 1234},
+		{"12.34 QR", 1234},
+		{"₡1.234,56", 123456},
+		{"$U12,34", 1234},
+	}
+
+	for _, test := range tests {
+		cents, err := ParsePrice(test.text)
+		if err != nil {
+			t.Errorf("ParsePrice(%q): %v", test.text, err)
+			continue
+		}
+
+		if cents != test.cents {
+			t.Errorf("ParsePrice(%q) = %d, want %d", test.text, cents, test.cents)
+		}
+	}
+}
+
+func TestParsePriceInvalid(t *testing.T) {
+	for _, text := range []string{"", "pуб.", "--", "S/."} {
+		if _, err := ParsePrice(text); err != ErrCannotParsePrice {
+			t.Errorf("ParsePrice(%q) error = %v, want ErrCannotParsePrice", text, err)
+		}
+	}
+}
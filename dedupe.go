@@ -0,0 +1,59 @@
+package steam
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// sharedResponse is a response read in full so that every caller gets a copy.
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+// SetGetDeduplication makes identical GET requests in flight at the same time
+// (e.g. several goroutines asking for the same price overview or inventory page)
+// share a single request to Steam, which saves on the rate limit.  Shared responses
+// are read in full before being returned, so it's not meant for streaming.
+func (session *Session) SetGetDeduplication(enabled bool) {
+	session.dedupeGets = enabled
+}
+
+func (transport *sessionTransport) shared(req *http.Request) (*http.Response, error) {
+	req, _ = withTrace(req)
+
+	// The request is done with the context of the first caller, but each caller
+	// only waits for as long as its own context.
+	flight := transport.session.getFlight.DoChan(req.URL.String(), func() (interface{}, error) {
+		resp, err := transport.roundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sharedResponse{resp, body}, nil
+	})
+
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case result := <-flight:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+
+		shared := result.Val.(*sharedResponse)
+		resp := *shared.resp
+		resp.Header = shared.resp.Header.Clone()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(shared.body))
+		resp.ContentLength = int64(len(shared.body))
+		resp.Request = req
+		return &resp, nil
+	}
+}
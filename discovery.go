@@ -0,0 +1,100 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Number of discovery queues giving a sale event card each.
+const DiscoveryQueuesPerDay = 3
+
+var ErrEmptyDiscoveryQueue = errors.New("discovery queue is empty")
+
+// GenerateDiscoveryQueue asks for a new discovery queue and returns its apps.
+// This is a store request, see PrepareForSteamStore.
+func (session *Session) GenerateDiscoveryQueue() ([]uint32, error) {
+	resp, err := session.client.PostForm("https://store.steampowered.com/explore/generatenewdiscoveryqueue", url.Values{
+		"queuetype": {"0"},
+		"sessionid": {session.sessionID},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Queue []uint32 `json:"queue"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Queue) == 0 {
+		return nil, ErrEmptyDiscoveryQueue
+	}
+
+	return response.Queue, nil
+}
+
+// ClearDiscoveryQueueItem marks @appID of the queue as seen.
+func (session *Session) ClearDiscoveryQueueItem(appID uint32) error {
+	resp, err := session.client.PostForm("https://store.steampowered.com/app/10", url.Values{
+		"appid_to_clear_from_queue": {strconv.FormatUint(uint64(appID), 10)},
+		"sessionid":                 {session.sessionID},
+	})
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ClearDiscoveryQueue generates a discovery queue and goes through all of it,
+// it returns the apps seen.
+func (session *Session) ClearDiscoveryQueue() ([]uint32, error) {
+	queue, err := session.GenerateDiscoveryQueue()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, appID := range queue {
+		if err = session.ClearDiscoveryQueueItem(appID); err != nil {
+			return queue[:i], err
+		}
+	}
+
+	return queue, nil
+}
+
+// ClearDiscoveryQueues clears @count queues in a row, e.g. DiscoveryQueuesPerDay
+// during sales, and returns how many were cleared.
+func (session *Session) ClearDiscoveryQueues(count int) (int, error) {
+	for i := 0; i < count; i++ {
+		if _, err := session.ClearDiscoveryQueue(); err != nil {
+			return i, err
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,49 @@
+package steam
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	dota2GemRegexp       = regexp.MustCompile("^(Ethereal|Prismatic|Inscribed|Kinetic|Spectator|Rune|Tournament)(?: Gem)?: (.+)$")
+	dota2AutographRegexp = regexp.MustCompile("^(?:Autographed by|Autograph): (.+)$")
+	dota2StyleRegexp     = regexp.MustCompile("^(?:Style|Styles|Unlocked Styles?): (.+)$")
+)
+
+type Dota2Gem struct {
+	Type string // e.g. "Prismatic", "Ethereal", "Inscribed"
+	Name string
+}
+
+type Dota2Attributes struct {
+	Gems       []*Dota2Gem
+	Styles     []string
+	Autographs []string
+}
+
+// Dota2Attributes parses the gems, styles and autographs listed in the descriptions
+// of a Dota 2 item, descriptions don't follow a strict format so this is best effort.
+func (desc *EconItemDesc) Dota2Attributes() *Dota2Attributes {
+	attributes := &Dota2Attributes{
+		Gems:       []*Dota2Gem{},
+		Styles:     []string{},
+		Autographs: []string{},
+	}
+
+	for _, line := range desc.descriptionLines() {
+		if m := dota2AutographRegexp.FindStringSubmatch(line); m != nil {
+			attributes.Autographs = append(attributes.Autographs, m[1])
+		} else if m := dota2GemRegexp.FindStringSubmatch(line); m != nil {
+			attributes.Gems = append(attributes.Gems, &Dota2Gem{Type: m[1], Name: m[2]})
+		} else if m := dota2StyleRegexp.FindStringSubmatch(line); m != nil {
+			for _, style := range strings.Split(m[1], ",") {
+				if style = strings.TrimSpace(style); len(style) != 0 {
+					attributes.Styles = append(attributes.Styles, style)
+				}
+			}
+		}
+	}
+
+	return attributes
+}
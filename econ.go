@@ -0,0 +1,109 @@
+package steam
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlBreakRegexp = regexp.MustCompile("(?i)<br\\s*/?>")
+	htmlTagRegexp   = regexp.MustCompile("<[^>]+>")
+)
+
+// EconItem is the canonical representation of an asset, it is what trade offers
+// send and receive and what inventory items and market listing assets convert to.
+type EconItem struct {
+	AssetID    uint64        `json:"assetid,string,omitempty"`
+	InstanceID uint64        `json:"instanceid,string,omitempty"`
+	ClassID    uint64        `json:"classid,string,omitempty"`
+	AppID      uint32        `json:"appid"`
+	ContextID  uint64        `json:"contextid,string"`
+	Amount     uint64        `json:"amount,string"`
+	Missing    bool          `json:"missing,omitempty"`
+	Desc       *EconItemDesc `json:"-"` /* May be nil  */
+}
+
+// MarketListingAsset is the asset attached to a market listing, note that
+// like inventory items the asset id is included as "id".
+type MarketListingAsset struct {
+	AppID          uint32 `json:"appid"`
+	ContextID      uint64 `json:"contextid,string"`
+	AssetID        uint64 `json:"id,string"`
+	ClassID        uint64 `json:"classid,string"`
+	InstanceID     uint64 `json:"instanceid,string"`
+	Amount         uint64 `json:"amount,string"`
+	OriginalAmount uint64 `json:"original_amount,string"`
+	Status         uint32 `json:"status"`
+	MarketHashName string `json:"market_hash_name"`
+}
+
+func (item *InventoryItem) ToEconItem() *EconItem {
+	return &EconItem{
+		AssetID:    item.AssetID,
+		InstanceID: item.InstanceID,
+		ClassID:    item.ClassID,
+		AppID:      item.AppID,
+		ContextID:  item.ContextID,
+		Amount:     item.Amount,
+		Desc:       item.Desc,
+	}
+}
+
+func (asset *MarketListingAsset) ToEconItem() *EconItem {
+	return &EconItem{
+		AssetID:    asset.AssetID,
+		InstanceID: asset.InstanceID,
+		ClassID:    asset.ClassID,
+		AppID:      asset.AppID,
+		ContextID:  asset.ContextID,
+		Amount:     asset.Amount,
+	}
+}
+
+// ToInventoryItem converts back to an inventory item, @desc may be nil
+// to keep the description the item has (if any).
+func (item *EconItem) ToInventoryItem(desc *EconItemDesc) *InventoryItem {
+	if desc == nil {
+		desc = item.Desc
+	}
+
+	return &InventoryItem{
+		AppID:      item.AppID,
+		ContextID:  item.ContextID,
+		AssetID:    item.AssetID,
+		ClassID:    item.ClassID,
+		InstanceID: item.InstanceID,
+		Amount:     item.Amount,
+		Desc:       desc,
+	}
+}
+
+func (item *EconItem) ToMarketListingAsset() *MarketListingAsset {
+	return &MarketListingAsset{
+		AppID:          item.AppID,
+		ContextID:      item.ContextID,
+		AssetID:        item.AssetID,
+		ClassID:        item.ClassID,
+		InstanceID:     item.InstanceID,
+		Amount:         item.Amount,
+		OriginalAmount: item.Amount,
+	}
+}
+
+// descriptionLines returns the text of every description, one line per entry
+// (or per <br> inside it) with the html stripped and empty lines removed.
+func (desc *EconItemDesc) descriptionLines() []string {
+	lines := []string{}
+	for _, d := range desc.Descriptions {
+		value := htmlBreakRegexp.ReplaceAllString(d.Value, "\n")
+		value = html.UnescapeString(htmlTagRegexp.ReplaceAllString(value, ""))
+		for _, line := range strings.Split(value, "\n") {
+			if line = strings.TrimSpace(line); len(line) != 0 {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,148 @@
+package steam
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const inspectLinkPrefix = "steam://rungame/730/"
+
+// ItemEnrichment is what an ItemEnricher knows about an item beyond its description.
+type ItemEnrichment struct {
+	FloatValue float64
+	PaintSeed  uint32
+	PaintIndex uint32
+	Extra      map[string]interface{}
+}
+
+// ItemEnricher is called with the inspect link of CS:GO items, it's meant to be
+// implemented on top of float checking services.
+type ItemEnricher interface {
+	Enrich(inspectLink string) (*ItemEnrichment, error)
+}
+
+// NopEnricher enriches nothing, it returns nil for every item.
+type NopEnricher struct{}
+
+func (NopEnricher) Enrich(inspectLink string) (*ItemEnrichment, error) {
+	return nil, nil
+}
+
+// HTTPEnricher asks URL?url=<inspect link> and expects a csgofloat style response:
+//
+//	{"iteminfo":{"floatvalue":0.1,"paintseed":1,"paintindex":2,...}}
+type HTTPEnricher struct {
+	Client *http.Client
+	URL    string
+}
+
+func (enricher *HTTPEnricher) Enrich(inspectLink string) (*ItemEnrichment, error) {
+	client := enricher.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Get(enricher.URL + "?" + url.Values{"url": {inspectLink}}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Inner map[string]interface{} `json:"iteminfo"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	enrichment := &ItemEnrichment{Extra: response.Inner}
+	if v, ok := response.Inner["floatvalue"].(float64); ok {
+		enrichment.FloatValue = v
+	}
+	if v, ok := response.Inner["paintseed"].(float64); ok {
+		enrichment.PaintSeed = uint32(v)
+	}
+	if v, ok := response.Inner["paintindex"].(float64); ok {
+		enrichment.PaintIndex = uint32(v)
+	}
+
+	return enrichment, nil
+}
+
+func findInspectLink(actions []*EconAction) (string, bool) {
+	for _, action := range actions {
+		if strings.HasPrefix(action.Link, inspectLinkPrefix) {
+			return action.Link, true
+		}
+	}
+
+	return "", false
+}
+
+// InventoryInspectLink fills in the inspect link of @item owned by @owner.
+func InventoryInspectLink(item *InventoryItem, owner SteamID) (string, bool) {
+	if item.Desc == nil {
+		return "", false
+	}
+
+	link, ok := findInspectLink(item.Desc.Actions)
+	if !ok {
+		return "", false
+	}
+
+	link = strings.Replace(link, "%owner_steamid%", owner.ToString(), -1)
+	link = strings.Replace(link, "%assetid%", strconv.FormatUint(item.AssetID, 10), -1)
+	return link, true
+}
+
+// ListingInspectLink fills in the inspect link of a market listing.
+func ListingInspectLink(desc *EconItemDesc, listingID, assetID uint64) (string, bool) {
+	if desc == nil {
+		return "", false
+	}
+
+	link, ok := findInspectLink(desc.MarketActions)
+	if !ok {
+		return "", false
+	}
+
+	link = strings.Replace(link, "%listingid%", strconv.FormatUint(listingID, 10), -1)
+	link = strings.Replace(link, "%assetid%", strconv.FormatUint(assetID, 10), -1)
+	return link, true
+}
+
+// EnrichInventory calls @enricher for every item of @items having an inspect link,
+// the result is keyed by asset id and does not have the items left unenriched.
+func EnrichInventory(items []InventoryItem, owner SteamID, enricher ItemEnricher) (map[uint64]*ItemEnrichment, error) {
+	enrichments := make(map[uint64]*ItemEnrichment)
+	for i := range items {
+		link, ok := InventoryInspectLink(&items[i], owner)
+		if !ok {
+			continue
+		}
+
+		enrichment, err := enricher.Enrich(link)
+		if err != nil {
+			return nil, err
+		}
+
+		if enrichment != nil {
+			enrichments[items[i].AssetID] = enrichment
+		}
+	}
+
+	return enrichments, nil
+}
@@ -0,0 +1,102 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Results (EResult) Steam puts in the X-eresult header of the Web API responses.
+const (
+	EResultOK                    = 1
+	EResultFail                  = 2
+	EResultInvalidParam          = 8
+	EResultBusy                  = 10
+	EResultInvalidState          = 11
+	EResultAccessDenied          = 15
+	EResultTimeout               = 16
+	EResultServiceUnavailable    = 20
+	EResultNotLoggedOn           = 21
+	EResultLimitExceeded         = 25
+	EResultRevoked               = 26
+	EResultExpired               = 27
+	EResultDuplicateRequest      = 29
+	EResultNoMatch               = 42
+	EResultRateLimitExceeded     = 84
+	EResultAccountLimitExceeded  = 85
+	EResultTwoFactorCodeMismatch = 88
+	EResultTooManyPending        = 97
+)
+
+var (
+	ErrEResultFail           = errors.New("request failed")
+	ErrEResultInvalidParam   = errors.New("invalid parameter")
+	ErrEResultBusy           = errors.New("steam is busy")
+	ErrEResultInvalidState   = errors.New("invalid state")
+	ErrEResultTimeout        = errors.New("steam timed out")
+	ErrEResultUnavailable    = errors.New("service unavailable")
+	ErrEResultNotLoggedOn    = errors.New("not logged on")
+	ErrEResultLimitExceeded  = errors.New("limit exceeded")
+	ErrEResultRevoked        = errors.New("revoked")
+	ErrEResultExpired        = errors.New("expired")
+	ErrEResultDuplicate      = errors.New("duplicate request")
+	ErrEResultNoMatch        = errors.New("no match")
+	ErrEResultRateLimited    = errors.New("rate limit exceeded")
+	ErrEResultTooManyPending = errors.New("too many pending requests")
+	ErrEResultTwoFactorCode  = errors.New("two factor code mismatch")
+	ErrEResultUnknownFailure = errors.New("request failed with an unknown result")
+)
+
+var eresultErrors = map[int]error{
+	EResultFail:                  ErrEResultFail,
+	EResultInvalidParam:          ErrEResultInvalidParam,
+	EResultBusy:                  ErrEResultBusy,
+	EResultInvalidState:          ErrEResultInvalidState,
+	EResultAccessDenied:          ErrAccessDenied,
+	EResultTimeout:               ErrEResultTimeout,
+	EResultServiceUnavailable:    ErrEResultUnavailable,
+	EResultNotLoggedOn:           ErrEResultNotLoggedOn,
+	EResultLimitExceeded:         ErrEResultLimitExceeded,
+	EResultRevoked:               ErrEResultRevoked,
+	EResultExpired:               ErrEResultExpired,
+	EResultDuplicateRequest:      ErrEResultDuplicate,
+	EResultNoMatch:               ErrEResultNoMatch,
+	EResultRateLimitExceeded:     ErrEResultRateLimited,
+	EResultAccountLimitExceeded:  ErrEResultRateLimited,
+	EResultTooManyPending:        ErrEResultTooManyPending,
+	EResultTwoFactorCodeMismatch: ErrEResultTwoFactorCode,
+}
+
+// EResultError Result is the EResult* Steam gave, errors.Is works with the
+// ErrEResult* above (and ErrAccessDenied) for the known ones and
+// ErrEResultUnknownFailure for the others.
+type EResultError struct {
+	Result int
+}
+
+func (err *EResultError) Error() string {
+	return fmt.Sprintf("%v (eresult %d)", err.Unwrap(), err.Result)
+}
+
+func (err *EResultError) Unwrap() error {
+	if known, ok := eresultErrors[err.Result]; ok {
+		return known
+	}
+
+	return ErrEResultUnknownFailure
+}
+
+// checkEResult returns an EResultError if @resp has an X-eresult header other
+// than EResultOK, and whether it had the header at all.
+func checkEResult(resp *http.Response) (bool, error) {
+	result := responseEResult(resp)
+	if result == 0 {
+		return false, nil
+	}
+
+	if result != EResultOK {
+		return true, &EResultError{Result: result}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,189 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	EventError = iota
+	EventTradeOffers
+	EventComments
+	EventInvites
+	EventItems
+	EventGifts
+	EventOfflineMessages
+	EventChatMessage
+	EventTyping
+	EventPersonaState
+	EventRelationship
+)
+
+var ErrDispatcherStarted = errors.New("event dispatcher already started")
+
+// Event is what the dispatcher sends, Count is set for notification events
+// (the new total, not the difference), Message for the ones coming from
+// the chat poller and Err for EventError.
+type Event struct {
+	Type    int
+	Count   uint32
+	Message *ChatMessage
+	Err     error
+}
+
+// EventDispatcher turns notification counts and, if given, chat poller messages
+// into a single stream of typed events so that one loop can handle them all.
+// Notification counts are polled every Interval and an event is sent whenever one
+// of them goes up.
+type EventDispatcher struct {
+	session  *Session
+	chat     *ChatPoller
+	Interval time.Duration
+	// Jitter moves each wait by up to this fraction of Interval, see Jitter.
+	Jitter float64
+	Clock  Clock
+
+	events chan *Event
+	lifecycle
+}
+
+// NewEventDispatcher @chat may be nil, if not it must be started separately,
+// @clock may be nil for SystemClock.
+func (session *Session) NewEventDispatcher(interval time.Duration, chat *ChatPoller, clock Clock) *EventDispatcher {
+	return &EventDispatcher{
+		session:  session,
+		chat:     chat,
+		Interval: interval,
+		Clock:    clockOrSystem(clock),
+		events:   make(chan *Event, 64),
+	}
+}
+
+// Events is closed once the dispatcher is stopped.
+func (dispatcher *EventDispatcher) Events() <-chan *Event {
+	return dispatcher.events
+}
+
+// Start dispatches events until Stop or @ctx is done, Events is then closed.
+func (dispatcher *EventDispatcher) Start(ctx context.Context) error {
+	return dispatcher.start(ctx, ErrDispatcherStarted, func() error {
+		dispatcher.events = make(chan *Event, cap(dispatcher.events))
+		return nil
+	}, func(stop chan struct{}) {
+		events := dispatcher.events
+		defer close(events)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dispatcher.pollNotifications(stop, events)
+		}()
+
+		if dispatcher.chat != nil {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				dispatcher.forwardChat(stop, events)
+			}()
+		}
+
+		wg.Wait()
+	})
+}
+
+func (dispatcher *EventDispatcher) send(stop chan struct{}, events chan *Event, event *Event) bool {
+	select {
+	case events <- event:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+func notificationEvents(last, counts *NotificationCounts) []*Event {
+	events := []*Event{}
+	check := func(eventType int, old, new uint32) {
+		if new > old {
+			events = append(events, &Event{Type: eventType, Count: new})
+		}
+	}
+
+	check(EventTradeOffers, last.TradeOffers, counts.TradeOffers)
+	check(EventComments, last.Comments, counts.Comments)
+	check(EventInvites, last.Invites, counts.Invites)
+	check(EventItems, last.Items, counts.Items)
+	check(EventGifts, last.Gifts, counts.Gifts)
+	check(EventOfflineMessages, last.OfflineMessages, counts.OfflineMessages)
+	return events
+}
+
+func (dispatcher *EventDispatcher) pollNotifications(stop chan struct{}, events chan *Event) {
+	// The chat poller reports the trade offers itself, unless told not to.
+	chatOffers := dispatcher.chat != nil && dispatcher.chat.TradeOfferInterval > 0
+
+	last := &NotificationCounts{}
+	for {
+		counts, err := dispatcher.session.GetNotificationCounts()
+		if err != nil {
+			if !dispatcher.send(stop, events, &Event{Type: EventError, Err: err}) {
+				return
+			}
+		} else {
+			if chatOffers {
+				last.TradeOffers = counts.TradeOffers
+			}
+
+			for _, event := range notificationEvents(last, counts) {
+				if !dispatcher.send(stop, events, event) {
+					return
+				}
+			}
+			last = counts
+		}
+
+		if !wait(dispatcher.Clock, stop, Jitter(dispatcher.Interval, dispatcher.Jitter)) {
+			return
+		}
+	}
+}
+
+// forwardChat reads the chat poller channels, they are the same whenever the
+// poller is started so this keeps forwarding across restarts.
+func (dispatcher *EventDispatcher) forwardChat(stop chan struct{}, events chan *Event) {
+	messages, tradeOffers := dispatcher.chat.Messages(), dispatcher.chat.TradeOffers()
+	for {
+		var message *ChatMessage
+
+		select {
+		case <-stop:
+			return
+		case pending := <-tradeOffers:
+			if !dispatcher.send(stop, events, &Event{Type: EventTradeOffers, Count: pending}) {
+				return
+			}
+			continue
+		case message = <-messages:
+		}
+
+		event := &Event{Message: message}
+		switch message.Type {
+		case MessageTypeSayText:
+			event.Type = EventChatMessage
+		case MessageTypeTyping:
+			event.Type = EventTyping
+		case MessageTypeStatus:
+			event.Type = EventPersonaState
+		case MessageTypeRelationship:
+			event.Type = EventRelationship
+		default:
+			continue
+		}
+
+		if !dispatcher.send(stop, events, event) {
+			return
+		}
+	}
+}
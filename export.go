@@ -0,0 +1,193 @@
+package steam
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+var (
+	ErrExportNotSlice       = errors.New("records must be a slice")
+	ErrExportNotCSVRecorder = errors.New("records cannot be written as CSV")
+)
+
+// CSVRecorder is implemented by the types WriteCSV knows to write.
+type CSVRecorder interface {
+	CSVHeader() []string
+	CSVRecord() []string
+}
+
+func formatUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (price *MarketItemPrice) CSVHeader() []string {
+	return []string{"date", "price", "count"}
+}
+
+func (price *MarketItemPrice) CSVRecord() []string {
+	date := price.Date
+	if t, err := price.Time(); err == nil {
+		date = t.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return []string{date, formatFloat(price.Price), formatUint(price.Volume())}
+}
+
+func (item *MarketSearchItem) CSVHeader() []string {
+	return []string{"name", "hash_name", "app_name", "sell_listings", "sell_price", "sell_price_text", "sale_price_text"}
+}
+
+func (item *MarketSearchItem) CSVRecord() []string {
+	return []string{
+		item.Name,
+		item.HashName,
+		item.AppName,
+		formatFloat(item.SellListings),
+		formatFloat(item.SellPrice),
+		item.SellPrice2,
+		item.SalePrice,
+	}
+}
+
+func (item *InventoryItem) CSVHeader() []string {
+	return []string{"appid", "contextid", "assetid", "classid", "instanceid", "amount", "market_hash_name", "tradable"}
+}
+
+func (item *InventoryItem) CSVRecord() []string {
+	var hashName, tradable string
+	if item.Desc != nil {
+		hashName = item.Desc.MarketHashName
+		tradable = strconv.FormatBool(item.Desc.Tradable != 0)
+	}
+
+	return []string{
+		formatUint(uint64(item.AppID)),
+		formatUint(item.ContextID),
+		formatUint(item.AssetID),
+		formatUint(item.ClassID),
+		formatUint(item.InstanceID),
+		formatUint(item.Amount),
+		hashName,
+		tradable,
+	}
+}
+
+func (listing *MarketListing) CSVHeader() []string {
+	return []string{"listingid", "time_created", "appid", "assetid", "market_hash_name", "price", "fee", "steam_fee", "publisher_fee", "currencyid", "status"}
+}
+
+func (listing *MarketListing) CSVRecord() []string {
+	var appID, assetID, hashName string
+	if listing.Asset != nil {
+		appID = formatUint(uint64(listing.Asset.AppID))
+		assetID = formatUint(listing.Asset.AssetID)
+		hashName = listing.Asset.MarketHashName
+	}
+
+	return []string{
+		formatUint(listing.ID),
+		strconv.FormatInt(listing.TimeCreated, 10),
+		appID,
+		assetID,
+		hashName,
+		formatUint(listing.Price),
+		formatUint(listing.Fee),
+		formatUint(listing.SteamFee),
+		formatUint(listing.PublisherFee),
+		formatUint(uint64(listing.CurrencyID)),
+		formatUint(uint64(listing.Status)),
+	}
+}
+
+func (purchase *MarketPurchase) CSVHeader() []string {
+	return []string{"listingid", "purchaseid", "time_sold", "appid", "assetid", "market_hash_name", "amount", "received", "steam_fee", "publisher_fee", "currencyid", "received_amount", "received_currencyid"}
+}
+
+func (purchase *MarketPurchase) CSVRecord() []string {
+	var appID, assetID, hashName string
+	if purchase.Asset != nil {
+		appID = formatUint(uint64(purchase.Asset.AppID))
+		assetID = formatUint(purchase.Asset.AssetID)
+		hashName = purchase.Asset.MarketHashName
+	}
+
+	fees := purchase.Fees()
+	return []string{
+		formatUint(purchase.ListingID),
+		formatUint(purchase.PurchaseID),
+		strconv.FormatInt(purchase.TimeSold, 10),
+		appID,
+		assetID,
+		hashName,
+		formatUint(fees.Amount),
+		formatUint(fees.Received),
+		formatUint(fees.SteamFee),
+		formatUint(fees.PublisherFee),
+		formatUint(uint64(purchase.CurrencyID)),
+		formatUint(purchase.ReceivedAmount),
+		formatUint(uint64(purchase.ReceivedCurrencyID)),
+	}
+}
+
+// WriteCSV writes @records, a slice of any of the types implementing CSVRecorder
+// (values or pointers), with a header line first.
+func WriteCSV(w io.Writer, records interface{}) error {
+	v := reflect.ValueOf(records)
+	if v.Kind() != reflect.Slice {
+		return ErrExportNotSlice
+	}
+
+	writer := csv.NewWriter(w)
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() != reflect.Ptr {
+			if !elem.CanAddr() {
+				return ErrExportNotCSVRecorder
+			}
+			elem = elem.Addr()
+		}
+
+		recorder, ok := elem.Interface().(CSVRecorder)
+		if !ok {
+			return ErrExportNotCSVRecorder
+		}
+
+		if i == 0 {
+			if err := writer.Write(recorder.CSVHeader()); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Write(recorder.CSVRecord()); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteNDJSON writes each element of the @records slice as JSON on its own line.
+func WriteNDJSON(w io.Writer, records interface{}) error {
+	v := reflect.ValueOf(records)
+	if v.Kind() != reflect.Slice {
+		return ErrExportNotSlice
+	}
+
+	encoder := json.NewEncoder(w)
+	for i := 0; i < v.Len(); i++ {
+		if err := encoder.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package steam
+
+import (
+	"net/url"
+	"strconv"
+)
+
+const apiDeviceAuthService = "https://api.steampowered.com/IDeviceAuthService/"
+
+// AuthorizedDevice is a device we authorized to share our library, Token is
+// what identifies it to DeauthorizeDevice.
+type AuthorizedDevice struct {
+	Token          uint64 `json:"auth_device_token,string"`
+	Name           string `json:"device_name"`
+	Pending        bool   `json:"is_pending"`
+	Canceled       bool   `json:"is_canceled"`
+	LastTimeUsed   int64  `json:"last_time_used"`
+	LastBorrowerID uint64 `json:"last_borrower_id,string"`
+	LastAppPlayed  uint32 `json:"last_app_played"`
+	Limited        bool   `json:"is_limited"`
+}
+
+// SharingAccount is a borrower of our library, or a lender of one we borrow.
+type SharingAccount struct {
+	SteamID     uint64 `json:"steamid,string"`
+	Pending     bool   `json:"is_pending"`
+	Canceled    bool   `json:"is_canceled"`
+	TimeCreated int64  `json:"time_created"`
+}
+
+func (session *Session) deviceAuthGet(method string, values url.Values, inner interface{}) error {
+	values.Set("access_token", session.oauth.Token)
+	values.Set("steamid", session.oauth.SteamID.ToString())
+	resp, err := session.client.Get(apiDeviceAuthService + method + "/v1/?" + values.Encode())
+	return session.decodeServiceResponse(resp, err, inner)
+}
+
+func (session *Session) deviceAuthPost(method string, values url.Values) error {
+	values.Set("steamid", session.oauth.SteamID.ToString())
+	resp, err := session.client.PostForm(apiDeviceAuthService+method+"/v1/?access_token="+url.QueryEscape(session.oauth.Token), values)
+	return session.decodeServiceResponse(resp, err, &struct{}{})
+}
+
+// GetAuthorizedDevices returns the devices we share our library with.
+func (session *Session) GetAuthorizedDevices(includeCanceled bool) ([]*AuthorizedDevice, error) {
+	var response struct {
+		Devices []*AuthorizedDevice `json:"devices"`
+	}
+
+	if err := session.deviceAuthGet("GetOwnAuthorizedDevices", url.Values{
+		"include_canceled": {strconv.FormatBool(includeCanceled)},
+	}, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Devices, nil
+}
+
+// AuthorizeDevice accepts the request of a device to borrow our library, @token
+// comes from the request.
+func (session *Session) AuthorizeDevice(token uint64) error {
+	return session.deviceAuthPost("AuthorizeRemoteDevice", url.Values{
+		"auth_device_token": {strconv.FormatUint(token, 10)},
+	})
+}
+
+func (session *Session) DeauthorizeDevice(token uint64) error {
+	return session.deviceAuthPost("DeauthorizeRemoteDevice", url.Values{
+		"auth_device_token": {strconv.FormatUint(token, 10)},
+	})
+}
+
+func (session *Session) getSharingAccounts(method, field string, includeCanceled, includePending bool) ([]*SharingAccount, error) {
+	var response map[string][]*SharingAccount
+	if err := session.deviceAuthGet(method, url.Values{
+		"include_canceled": {strconv.FormatBool(includeCanceled)},
+		"include_pending":  {strconv.FormatBool(includePending)},
+	}, &response); err != nil {
+		return nil, err
+	}
+
+	return response[field], nil
+}
+
+// GetBorrowers returns the accounts allowed to borrow our library.
+func (session *Session) GetBorrowers(includeCanceled, includePending bool) ([]*SharingAccount, error) {
+	return session.getSharingAccounts("GetAuthorizedBorrowers", "borrowers", includeCanceled, includePending)
+}
+
+// GetLenders returns the accounts whose library we are allowed to borrow.
+func (session *Session) GetLenders(includeCanceled, includePending bool) ([]*SharingAccount, error) {
+	return session.getSharingAccounts("GetAuthorizedAsBorrower", "lenders", includeCanceled, includePending)
+}
+
+func borrowerValues(borrowers []SteamID) url.Values {
+	values := url.Values{}
+	for i, borrower := range borrowers {
+		values.Set("steamid_borrower["+strconv.Itoa(i)+"]", borrower.ToString())
+	}
+
+	return values
+}
+
+func (session *Session) AddBorrowers(borrowers ...SteamID) error {
+	return session.deviceAuthPost("AddAuthorizedBorrowers", borrowerValues(borrowers))
+}
+
+func (session *Session) RemoveBorrowers(borrowers ...SteamID) error {
+	return session.deviceAuthPost("RemoveAuthorizedBorrowers", borrowerValues(borrowers))
+}
@@ -0,0 +1,88 @@
+package steam
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// How many assets of the first page InventoryFingerprint hashes.
+const fingerprintPageSize = 100
+
+// GetInventoryFingerprint hashes the total count of the inventory along with the
+// asset IDs and amounts of its first page, which has the newest items.  It's one
+// small request instead of the whole inventory, so it's meant to be kept after a
+// full fetch and compared later to only fetch again the inventories that changed.
+// Changes in the amount of an old stack past the first page are not seen.
+func (session *Session) GetInventoryFingerprint(sid SteamID, appID, contextID uint64) (string, error) {
+	resp, err := session.client.Get(fmt.Sprintf(InventoryEndpoint, sid, appID, contextID) + url.Values{
+		"l":     {session.language},
+		"count": {strconv.Itoa(fingerprintPageSize)},
+	}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Asset struct {
+		AssetID uint64 `json:"assetid,string"`
+		Amount  uint64 `json:"amount,string"`
+	}
+
+	type Response struct {
+		Assets              []Asset `json:"assets"`
+		Success             int     `json:"success"`
+		TotalInventoryCount int     `json:"total_inventory_count"`
+		ErrorMsg            string  `json:"error"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+
+	if response.Success == 0 && len(response.ErrorMsg) != 0 {
+		return "", errors.New(response.ErrorMsg)
+	}
+
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%d;", response.TotalInventoryCount)
+	for _, asset := range response.Assets {
+		fmt.Fprintf(hash, "%d:%d;", asset.AssetID, asset.Amount)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GetInventoryIfChanged fetches the inventory only if its fingerprint isn't @last
+// anymore, the new fingerprint is returned either way and items is nil when
+// it didn't change.
+func (session *Session) GetInventoryIfChanged(sid SteamID, appID, contextID uint64, last string) ([]InventoryItem, string, error) {
+	fingerprint, err := session.GetInventoryFingerprint(sid, appID, contextID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if fingerprint == last {
+		return nil, fingerprint, nil
+	}
+
+	items, err := session.GetInventory(sid, appID, contextID, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, fingerprint, nil
+}
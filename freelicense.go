@@ -0,0 +1,98 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+var (
+	ErrCannotAddLicense = errors.New("unable to add free license")
+	ErrAppNotFree       = errors.New("app is not free")
+)
+
+// AddFreeLicense adds the free package @subID to the account.  This is a store
+// request, see PrepareForSteamStore.
+func (session *Session) AddFreeLicense(subID uint64) error {
+	resp, err := session.client.PostForm("https://store.steampowered.com/freelicense/addfreelicense/"+strconv.FormatUint(subID, 10), url.Values{
+		"ajax":      {"true"},
+		"sessionid": {session.sessionID},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Success is an empty array, failures have a success code.
+	type Response struct {
+		Success *int `json:"success"`
+	}
+
+	var response Response
+	if json.Unmarshal(body, &response) == nil && response.Success != nil && *response.Success != 1 {
+		return ErrCannotAddLicense
+	}
+
+	return nil
+}
+
+// AddFreeAppLicense adds the free package of @appID, ErrAppNotFree is returned
+// for apps that must be bought.
+func (session *Session) AddFreeAppLicense(appID uint32) error {
+	id := strconv.FormatUint(uint64(appID), 10)
+	resp, err := session.client.Get("https://store.steampowered.com/api/appdetails/?" + url.Values{
+		"appids":  {id},
+		"filters": {"basic,packages"},
+	}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response map[string]struct {
+		Success bool `json:"success"`
+		Data    struct {
+			IsFree   bool     `json:"is_free"`
+			Packages []uint64 `json:"packages"`
+		} `json:"data"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	app := response[id]
+	if !app.Success || len(app.Data.Packages) == 0 {
+		return ErrCannotAddLicense
+	}
+
+	if !app.Data.IsFree {
+		return ErrAppNotFree
+	}
+
+	return session.AddFreeLicense(app.Data.Packages[0])
+}
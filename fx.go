@@ -0,0 +1,196 @@
+package steam
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoFXRate        = errors.New("no exchange rate for the currencies at this date")
+	ErrUnknownCurrency = errors.New("unknown currency")
+)
+
+// HistoricalFXRateSource is FXRateSource at a given date, for reporting amounts at
+// the rate of the day they happened.
+type HistoricalFXRateSource interface {
+	// RateAt returns how much of @to one unit of @from was worth at @date.
+	RateAt(from, to string, date time.Time) (float64, error)
+}
+
+type currentRates struct {
+	source FXRateSource
+}
+
+func (rates *currentRates) RateAt(from, to string, date time.Time) (float64, error) {
+	return rates.source.Rate(from, to)
+}
+
+// CurrentRates uses the rates of @source whatever the date.
+func CurrentRates(source FXRateSource) HistoricalFXRateSource {
+	return &currentRates{source}
+}
+
+type datedRate struct {
+	date time.Time
+	rate float64
+}
+
+// FXRateTable keeps dated rates in memory, the rate at a date is the last one set at
+// or before it.  Rates can be looked up both ways, @from/@to or @to/@from inverted.
+// It also implements FXRateSource with the latest rates.
+type FXRateTable struct {
+	mutex sync.RWMutex
+	rates map[string][]datedRate
+}
+
+func NewFXRateTable() *FXRateTable {
+	return &FXRateTable{rates: make(map[string][]datedRate)}
+}
+
+func (table *FXRateTable) Set(from, to string, date time.Time, rate float64) {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	key := from + "/" + to
+	rates := table.rates[key]
+	i := sort.Search(len(rates), func(i int) bool { return !rates[i].date.Before(date) })
+	if i < len(rates) && rates[i].date.Equal(date) {
+		rates[i].rate = rate
+		return
+	}
+
+	rates = append(rates, datedRate{})
+	copy(rates[i+1:], rates[i:])
+	rates[i] = datedRate{date, rate}
+	table.rates[key] = rates
+}
+
+func (table *FXRateTable) lookup(key string, date time.Time) (float64, bool) {
+	rates := table.rates[key]
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].date.After(date) })
+	if i == 0 {
+		return 0, false
+	}
+
+	return rates[i-1].rate, true
+}
+
+func (table *FXRateTable) RateAt(from, to string, date time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	table.mutex.RLock()
+	defer table.mutex.RUnlock()
+
+	if rate, ok := table.lookup(from+"/"+to, date); ok {
+		return rate, nil
+	}
+
+	if rate, ok := table.lookup(to+"/"+from, date); ok && rate != 0 {
+		return 1 / rate, nil
+	}
+
+	return 0, ErrNoFXRate
+}
+
+func (table *FXRateTable) Rate(from, to string) (float64, error) {
+	return table.RateAt(from, to, time.Now())
+}
+
+// MarketCurrencyCode returns the ISO code of a market currency ID, the wallet ones
+// (e.g. 2001 for USD) included.
+func MarketCurrencyCode(currencyID uint32) (string, error) {
+	if currencyID > 2000 {
+		currencyID -= 2000
+	}
+
+	code, ok := CurrencyCodes[strconv.FormatUint(uint64(currencyID), 10)]
+	if !ok {
+		return "", ErrUnknownCurrency
+	}
+
+	return code, nil
+}
+
+// ConvertFees converts @fees (in cents of @from) to @to at @date, each component is
+// rounded on its own so that Amount is still their sum.
+func ConvertFees(fees *MarketFees, from, to string, date time.Time, fx HistoricalFXRateSource) (*MarketFees, error) {
+	rate, err := fx.RateAt(from, to, date)
+	if err != nil {
+		return nil, err
+	}
+
+	convert := func(amount uint64) uint64 {
+		return uint64(math.Round(float64(amount) * rate))
+	}
+
+	converted := &MarketFees{
+		Received:     convert(fees.Received),
+		SteamFee:     convert(fees.SteamFee),
+		PublisherFee: convert(fees.PublisherFee),
+	}
+	converted.Amount = converted.Received + converted.SteamFee + converted.PublisherFee
+	return converted, nil
+}
+
+// ReportingFees is Fees converted to @to at the date of the sale.
+func (purchase *MarketPurchase) ReportingFees(to string, fx HistoricalFXRateSource) (*MarketFees, error) {
+	from, err := MarketCurrencyCode(purchase.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConvertFees(purchase.Fees(), from, to, time.Unix(purchase.TimeSold, 0), fx)
+}
+
+// ConvertPriceHistory returns a copy of @prices converted from @from to @to at the
+// date of every point.
+func ConvertPriceHistory(prices []*MarketItemPrice, from, to string, fx HistoricalFXRateSource) ([]*MarketItemPrice, error) {
+	converted := make([]*MarketItemPrice, len(prices))
+	for i, price := range prices {
+		date, err := price.Time()
+		if err != nil {
+			return nil, err
+		}
+
+		rate, err := fx.RateAt(from, to, date)
+		if err != nil {
+			return nil, err
+		}
+
+		point := *price
+		point.Price *= rate
+		converted[i] = &point
+	}
+
+	return converted, nil
+}
+
+// ReportingAppraiser converts what Appraiser says (in cents of From) to cents of
+// To at the current date of Clock.
+type ReportingAppraiser struct {
+	Appraiser ItemAppraiser
+	From      string
+	To        string
+	FX        HistoricalFXRateSource
+	Clock     Clock
+}
+
+func (appraiser *ReportingAppraiser) Appraise(item *EconItem) (uint64, error) {
+	value, err := appraiser.Appraiser.Appraise(item)
+	if err != nil {
+		return 0, err
+	}
+
+	rate, err := appraiser.FX.RateAt(appraiser.From, appraiser.To, clockOrSystem(appraiser.Clock).Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(math.Round(float64(value) * rate)), nil
+}
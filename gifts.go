@@ -0,0 +1,152 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	ErrCannotSendGift   = errors.New("unable to send gift")
+	ErrCannotAnswerGift = errors.New("unable to accept or decline gift")
+)
+
+type PendingGift struct {
+	ID         uint64
+	Name       string
+	SenderName string
+}
+
+// GiftMessage is the card that comes with a gift, Sentiment is e.g. "Best Wishes".
+type GiftMessage struct {
+	Name      string
+	Message   string
+	Sentiment string
+	Signature string
+}
+
+// GetPendingGifts scrapes the gifts waiting to be accepted from our inventory page.
+func (session *Session) GetPendingGifts() ([]*PendingGift, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/profiles/" + session.oauth.SteamID.ToString() + "/inventory/")
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	gifts := []*PendingGift{}
+	doc.Find("[id^=pending_gift_]").Each(func(i int, s *goquery.Selection) {
+		id, err := strconv.ParseUint(strings.TrimPrefix(s.AttrOr("id", ""), "pending_gift_"), 10, 64)
+		if err != nil {
+			return
+		}
+
+		gifts = append(gifts, &PendingGift{
+			ID:         id,
+			Name:       strings.TrimSpace(s.Find("h1").First().Text()),
+			SenderName: strings.TrimSpace(s.Find(".pending_gift_sender, .gift_sender").First().Text()),
+		})
+	})
+
+	return gifts, nil
+}
+
+func (session *Session) answerGift(giftID uint64, action string) error {
+	resp, err := session.client.PostForm(fmt.Sprintf("https://steamcommunity.com/gifts/%d/%s", giftID, action), url.Values{
+		"sessionid": {session.sessionID},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int `json:"success"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Success != 1 {
+		return ErrCannotAnswerGift
+	}
+
+	return nil
+}
+
+// AcceptGift adds a pending gift to our library.
+func (session *Session) AcceptGift(giftID uint64) error {
+	return session.answerGift(giftID, "acceptunpack")
+}
+
+// DeclineGift sends a pending gift back to the sender.
+func (session *Session) DeclineGift(giftID uint64) error {
+	return session.answerGift(giftID, "decline")
+}
+
+// SendGift sends @giftID (a gift in our inventory, or one bought for a friend at
+// checkout) to @friend.  This is a store request, see PrepareForSteamStore.
+func (session *Session) SendGift(giftID uint64, friend SteamID, message *GiftMessage) error {
+	resp, err := session.client.PostForm("https://store.steampowered.com/checkout/sendgiftsubmit/", url.Values{
+		"GifteeAccountID": {strconv.FormatUint(uint64(friend.GetAccountID()), 10)},
+		"GifteeEmail":     {""},
+		"GifteeName":      {message.Name},
+		"GiftMessage":     {message.Message},
+		"GiftSentiment":   {message.Sentiment},
+		"GiftSignature":   {message.Signature},
+		"GiftGID":         {strconv.FormatUint(giftID, 10)},
+		"SessionID":       {session.sessionID},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success int `json:"success"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Success != 1 {
+		return ErrCannotSendGift
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// InviteToGroup invites @sids to @groupID, they must all be friends of ours.
+func (session *Session) InviteToGroup(groupID SteamID, sids ...SteamID) error {
+	values := url.Values{
+		"json":      {"1"},
+		"type":      {"groupInvite"},
+		"group":     {groupID.ToString()},
+		"sessionID": {session.sessionID},
+	}
+
+	if len(sids) == 1 {
+		values.Set("invitee", sids[0].ToString())
+	} else {
+		invitees := make([]string, len(sids))
+		for i, sid := range sids {
+			invitees[i] = sid.ToString()
+		}
+
+		list, err := json.Marshal(invitees)
+		if err != nil {
+			return err
+		}
+
+		values.Set("invitee_list", string(list))
+	}
+
+	resp, err := session.client.PostForm("https://steamcommunity.com/actions/GroupInvite", values)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Results string `json:"results"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Results != "OK" {
+		return errors.New(response.Results)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package steam
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarketListingsURL is the item page of the market, formatted with the app ID and
+// the encoded market hash name.
+const MarketListingsURL = "https://steamcommunity.com/market/listings/%d/%s"
+
+// EncodeMarketHashName escapes @marketHashName for the path of the market URLs the
+// way the market pages do (encodeURIComponent), so every byte but letters, digits
+// and -_.!~*'() is percent-encoded: spaces, '#', '?', '/', '&', '+' and UTF-8
+// (e.g. "StatTrak™ AK-47 | Redline (Field-Tested)").
+func EncodeMarketHashName(marketHashName string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(marketHashName); i++ {
+		c := marketHashName[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			strings.IndexByte("-_.!~*'()", c) != -1:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0xf])
+		}
+	}
+
+	return b.String()
+}
+
+// ListingURL returns the market page of the item.
+func ListingURL(appID uint64, marketHashName string) string {
+	return fmt.Sprintf(MarketListingsURL, appID, EncodeMarketHashName(marketHashName))
+}
@@ -0,0 +1,60 @@
+package steam
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestEncodeMarketHashName(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"Mann Co. Supply Crate Key", "Mann%20Co.%20Supply%20Crate%20Key"},
+		{"AK-47 | Redline (Field-Tested)", "AK-47%20%7C%20Redline%20(Field-Tested)"},
+		{"StatTrak™ AK-47 | Redline (Field-Tested)", "StatTrak%E2%84%A2%20AK-47%20%7C%20Redline%20(Field-Tested)"},
+		{"★ Karambit | Doppler (Factory New)", "%E2%98%85%20Karambit%20%7C%20Doppler%20(Factory%20New)"},
+		{"★ StatTrak™ Bayonet", "%E2%98%85%20StatTrak%E2%84%A2%20Bayonet"},
+		{"753-Sack of Gems", "753-Sack%20of%20Gems"},
+		{"Sticker | 100% Pure", "Sticker%20%7C%20100%25%20Pure"},
+		{"AC/DC", "AC%2FDC"},
+		{"Rock & Roll + More", "Rock%20%26%20Roll%20%2B%20More"},
+		{"#1 Fan?", "%231%20Fan%3F"},
+		{"Key=Value", "Key%3DValue"},
+		{"Tom's Hat!", "Tom's%20Hat!"},
+		{"a_b.c~d*e", "a_b.c~d*e"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		encoded := EncodeMarketHashName(test.name)
+		if encoded != test.encoded {
+			t.Errorf("EncodeMarketHashName(%q) = %q, want %q", test.name, encoded, test.encoded)
+			continue
+		}
+
+		// The path must give back the name once decoded, with nothing taken
+		// as a path separator, query or fragment.
+		decoded, err := url.PathUnescape(encoded)
+		if err != nil || decoded != test.name {
+			t.Errorf("PathUnescape(%q) = %q, %v, want %q", encoded, decoded, err, test.name)
+		}
+	}
+}
+
+func TestListingURL(t *testing.T) {
+	got := ListingURL(730, "StatTrak™ AK-47 | Redline (Field-Tested)")
+	want := "https://steamcommunity.com/market/listings/730/StatTrak%E2%84%A2%20AK-47%20%7C%20Redline%20(Field-Tested)"
+	if got != want {
+		t.Fatalf("ListingURL = %q, want %q", got, want)
+	}
+
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		t.Fatalf("ListingURL has a query or fragment: %q", got)
+	}
+}
@@ -0,0 +1,133 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+var (
+	ErrCannotFindItemNameID = errors.New("unable to find item name id")
+	ErrCannotLoadHistogram  = errors.New("unable to load order histogram at this time")
+)
+
+var itemNameIDRegexp = regexp.MustCompile(`Market_LoadOrderSpread\(\s*([0-9]+)\s*\)`)
+
+// MarketOrderLevel is a point of the order graph, Price in cents and Quantity
+// the number of orders at this price or better.
+type MarketOrderLevel struct {
+	Price    uint64
+	Quantity uint64
+}
+
+type MarketOrderHistogram struct {
+	RawResponse
+
+	Success         int             `json:"success"`
+	HighestBuyOrder uint64          `json:"highest_buy_order,string"`
+	LowestSellOrder uint64          `json:"lowest_sell_order,string"`
+	BuyOrderGraph   [][]interface{} `json:"buy_order_graph"`
+	SellOrderGraph  [][]interface{} `json:"sell_order_graph"`
+
+	// Parsed from the graphs above, best price first.
+	BuyOrders  []*MarketOrderLevel `json:"-"`
+	SellOrders []*MarketOrderLevel `json:"-"`
+}
+
+func parseOrderGraph(graph [][]interface{}) []*MarketOrderLevel {
+	levels := []*MarketOrderLevel{}
+	for _, point := range graph {
+		if len(point) < 2 {
+			continue
+		}
+
+		price, ok := point[0].(float64)
+		if !ok {
+			continue
+		}
+
+		quantity, ok := point[1].(float64)
+		if !ok {
+			continue
+		}
+
+		levels = append(levels, &MarketOrderLevel{
+			Price:    uint64(math.Round(price * 100)),
+			Quantity: uint64(quantity),
+		})
+	}
+
+	return levels
+}
+
+// GetMarketItemNameID finds the item_nameid the order histogram wants in the item page.
+func (session *Session) GetMarketItemNameID(appID uint64, marketHashName string) (uint64, error) {
+	resp, err := session.client.Get(ListingURL(appID, marketHashName))
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	m := itemNameIDRegexp.FindSubmatch(body)
+	if m == nil {
+		return 0, ErrCannotFindItemNameID
+	}
+
+	return strconv.ParseUint(string(m[1]), 10, 64)
+}
+
+// GetMarketOrderHistogram @country may be empty for the session country.
+func (session *Session) GetMarketOrderHistogram(itemNameID uint64, country, currencyID string) (*MarketOrderHistogram, error) {
+	params := session.withCountry(url.Values{
+		"item_nameid": {strconv.FormatUint(itemNameID, 10)},
+		"currency":    {currencyID},
+		"language":    {session.language},
+		"two_factor":  {"0"},
+	})
+	if len(country) != 0 {
+		params.Set("country", country)
+	}
+
+	resp, err := session.client.Get("https://steamcommunity.com/market/itemordershistogram?" + params.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	histogram := &MarketOrderHistogram{}
+	if err = session.decodeJSON(resp.Body, histogram); err != nil {
+		return nil, err
+	}
+
+	if histogram.Success != 1 {
+		return nil, ErrCannotLoadHistogram
+	}
+
+	histogram.BuyOrders = parseOrderGraph(histogram.BuyOrderGraph)
+	histogram.SellOrders = parseOrderGraph(histogram.SellOrderGraph)
+	return histogram, nil
+}
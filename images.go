@@ -0,0 +1,155 @@
+package steam
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ItemImageBaseURL is where the icon_url of the item descriptions are relative to.
+const ItemImageBaseURL = "https://community.cloudflare.steamstatic.com/economy/image/"
+
+// ItemImageURL returns the image of @iconURL (IconURL or IconLargeURL of a
+// description) fitting in @width x @height, the aspect ratio is kept.  Zero sizes
+// give the image as it was uploaded.
+func ItemImageURL(iconURL string, width, height int) string {
+	if len(iconURL) == 0 {
+		return ""
+	}
+
+	if width == 0 && height == 0 {
+		return ItemImageBaseURL + iconURL
+	}
+
+	return fmt.Sprintf("%s%s/%dfx%df", ItemImageBaseURL, iconURL, width, height)
+}
+
+// ImageURL is the large image when there is one.
+func (desc *EconItemDesc) ImageURL(width, height int) string {
+	if len(desc.IconLargeURL) != 0 {
+		return ItemImageURL(desc.IconLargeURL, width, height)
+	}
+
+	return ItemImageURL(desc.IconURL, width, height)
+}
+
+// ImageDownloader downloads images to Dir, once: images already there are not
+// downloaded again.
+type ImageDownloader struct {
+	Client *http.Client
+	Dir    string
+	// Parallel is how many images DownloadAll downloads at once.
+	Parallel int
+}
+
+// ImageResult is the file @URL was downloaded to, or the error doing it.
+type ImageResult struct {
+	URL  string
+	Path string
+	Err  error
+}
+
+// NewImageDownloader @client may be nil for http.DefaultClient, CDN images need
+// no session.
+func NewImageDownloader(client *http.Client, dir string) *ImageDownloader {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &ImageDownloader{
+		Client:   client,
+		Dir:      dir,
+		Parallel: 4,
+	}
+}
+
+// CachePath is the file @imageURL is downloaded to.
+func (downloader *ImageDownloader) CachePath(imageURL string) string {
+	sum := sha1.Sum([]byte(imageURL))
+	ext := path.Ext(strings.SplitN(imageURL, "?", 2)[0])
+	if len(ext) > 5 || strings.Contains(ext, "/") {
+		ext = ""
+	}
+
+	return filepath.Join(downloader.Dir, hex.EncodeToString(sum[:])+ext)
+}
+
+// Download returns the file of @imageURL, downloading it first if needed.
+func (downloader *ImageDownloader) Download(imageURL string) (string, error) {
+	file := downloader.CachePath(imageURL)
+	if _, err := os.Stat(file); err == nil {
+		return file, nil
+	}
+
+	resp, err := downloader.Client.Get(imageURL)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	if err = os.MkdirAll(downloader.Dir, 0755); err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file first so that a failed download doesn't leave
+	// a truncated image in the cache.
+	tmp, err := ioutil.TempFile(downloader.Dir, ".download-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return "", err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return "", err
+	}
+
+	return file, os.Rename(tmp.Name(), file)
+}
+
+// DownloadAll downloads @imageURLs, Parallel at once, the results are in the
+// same order.
+func (downloader *ImageDownloader) DownloadAll(imageURLs []string) []*ImageResult {
+	parallel := downloader.Parallel
+	if parallel <= 0 {
+		parallel = 1
+	}
+
+	results := make([]*ImageResult, len(imageURLs))
+	slots := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, imageURL := range imageURLs {
+		results[i] = &ImageResult{URL: imageURL}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(result *ImageResult) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result.Path, result.Err = downloader.Download(result.URL)
+		}(results[i])
+	}
+
+	wg.Wait()
+	return results
+}
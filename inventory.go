@@ -4,14 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"net/http"
 	"net/url"
-	"regexp"
 	"strconv"
 )
 
 const (
-	InventoryEndpoint = "http://steamcommunity.com/inventory/%d/%d/%d?"
+	InventoryEndpoint        = "http://steamcommunity.com/inventory/%d/%d/%d?"
+	PartnerInventoryEndpoint = "https://steamcommunity.com/tradeoffer/new/partnerinventory/?"
 )
 
 type ItemTag struct {
@@ -50,7 +50,10 @@ type InventoryAppStats struct {
 	Contexts         map[string]*InventoryContext `json:"rgContexts"`
 }
 
-var inventoryContextRegexp = regexp.MustCompile("var g_rgAppContextData = (.*?);")
+var (
+	ErrCannotLoadInventory      = errors.New("unable to load inventory at this time")
+	ErrCannotFindAppContextData = errors.New("unable to find inventory app context data")
+)
 
 func (session *Session) fetchInventory(
 	sid SteamID,
@@ -97,8 +100,11 @@ func (session *Session) fetchInventory(
 		ErrorMsg            string          `json:"error"`
 	}
 
+	sample := newPayloadSample(resp.Body)
+	defer recoverPayload(&err, sample)
+
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = json.NewDecoder(sample).Decode(&response); err != nil {
 		return false, 0, err
 	}
 
@@ -197,6 +203,143 @@ func (session *Session) GetFilterableInventory(sid SteamID, appID, contextID uin
 	return items, nil
 }
 
+func (session *Session) fetchPartnerInventory(
+	sid SteamID,
+	token string,
+	appID, contextID, start uint64,
+	filters []Filter,
+	items *[]InventoryItem,
+) (hasMore bool, moreStart uint64, err error) {
+	params := url.Values{
+		"sessionid": {session.sessionID},
+		"partner":   {sid.ToString()},
+		"appid":     {strconv.FormatUint(appID, 10)},
+		"contextid": {strconv.FormatUint(contextID, 10)},
+		"l":         {session.language},
+	}
+
+	if start != 0 {
+		params.Set("start", strconv.FormatUint(start, 10))
+	}
+
+	req, err := http.NewRequest(http.MethodGet, PartnerInventoryEndpoint+params.Encode(), nil)
+	if err != nil {
+		return false, 0, err
+	}
+
+	referer := url.Values{
+		"partner": {strconv.FormatUint(uint64(sid.GetAccountID()), 10)},
+	}
+	if len(token) != 0 {
+		referer.Set("token", token)
+	}
+	req.Header.Add("Referer", "https://steamcommunity.com/tradeoffer/new/?"+referer.Encode())
+	req.Header.Add("X-Requested-With", "XMLHttpRequest")
+
+	resp, err := session.client.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return false, 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, 0, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Asset struct {
+		AssetID    uint64 `json:"id,string"`
+		ClassID    uint64 `json:"classid,string"`
+		InstanceID uint64 `json:"instanceid,string"`
+		Amount     uint64 `json:"amount,string"`
+		Pos        uint32 `json:"pos"`
+	}
+
+	// The trade window still uses the old inventory format, where
+	// assets and descriptions are keyed objects instead of arrays and
+	// "more_start" is false when there is nothing more.
+	type Response struct {
+		Success      bool                     `json:"success"`
+		Error        string                   `json:"error"`
+		Inventory    map[string]*Asset        `json:"rgInventory"`
+		Descriptions map[string]*EconItemDesc `json:"rgDescriptions"`
+		More         bool                     `json:"more"`
+		MoreStart    interface{}              `json:"more_start"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return false, 0, err
+	}
+
+	if !response.Success {
+		if len(response.Error) != 0 {
+			return false, 0, errors.New(response.Error)
+		}
+
+		return false, 0, ErrCannotLoadInventory
+	}
+
+	for _, asset := range response.Inventory {
+		item := InventoryItem{
+			AppID:      uint32(appID),
+			ContextID:  contextID,
+			AssetID:    asset.AssetID,
+			ClassID:    asset.ClassID,
+			InstanceID: asset.InstanceID,
+			Amount:     asset.Amount,
+			Desc:       response.Descriptions[fmt.Sprintf("%d_%d", asset.ClassID, asset.InstanceID)],
+		}
+
+		add := true
+		for _, filter := range filters {
+			add = filter(&item)
+			if !add {
+				break
+			}
+		}
+
+		if add {
+			*items = append(*items, item)
+		}
+	}
+
+	if !response.More {
+		return false, 0, nil
+	}
+
+	if next, ok := response.MoreStart.(float64); ok {
+		return true, uint64(next), nil
+	}
+
+	return false, 0, nil
+}
+
+// GetPartnerInventory fetches @sid's inventory through the trade window, this
+// is what to use when composing offers, @token is the partner's trade token
+// and is only needed if you're not friends.
+func (session *Session) GetPartnerInventory(sid SteamID, token string, appID, contextID uint64, filters []Filter) ([]InventoryItem, error) {
+	items := []InventoryItem{}
+	start := uint64(0)
+
+	for {
+		hasMore, moreStart, err := session.fetchPartnerInventory(sid, token, appID, contextID, start, filters, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasMore {
+			break
+		}
+
+		start = moreStart
+	}
+
+	return items, nil
+}
+
 func (session *Session) GetInventoryAppStats(sid SteamID) (map[string]InventoryAppStats, error) {
 	resp, err := session.client.Get("https://steamcommunity.com/profiles/" + sid.ToString() + "/inventory")
 	if resp != nil {
@@ -207,18 +350,27 @@ func (session *Session) GetInventoryAppStats(sid SteamID) (map[string]InventoryA
 		return nil, err
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	var data json.RawMessage
+	found, err := scriptVariable(resp.Body, "g_rgAppContextData", &data)
 	if err != nil {
 		return nil, err
 	}
 
-	m := inventoryContextRegexp.FindSubmatch(body)
-	if m == nil || len(m) != 2 {
-		return nil, err
+	if !found {
+		return nil, ErrCannotFindAppContextData
 	}
 
+	// Empty inventories are an empty array rather than an object.
 	inven := map[string]InventoryAppStats{}
-	if err = json.Unmarshal(m[1], &inven); err != nil {
+	if string(data) == "[]" {
+		return inven, nil
+	}
+
+	if err = json.Unmarshal(data, &inven); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,234 @@
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	InventoryEventOther = iota
+	InventoryEventTrade
+	InventoryEventMarketPurchase
+	InventoryEventMarketListing
+	InventoryEventMarketListingCancelled
+	InventoryEventCrafting
+	InventoryEventUnpacked
+	InventoryEventGift
+)
+
+var ErrCannotLoadInventoryHistory = errors.New("unable to load inventory history at this time")
+
+// The description prefixes of the known event types, in english, see SetLanguage.
+var inventoryEventPrefixes = []struct {
+	prefix    string
+	eventType int
+}{
+	{"You traded with", InventoryEventTrade},
+	{"You purchased an item on the Community Market", InventoryEventMarketPurchase},
+	{"You listed an item on the Community Market", InventoryEventMarketListing},
+	{"You canceled a listing on the Community Market", InventoryEventMarketListingCancelled},
+	{"Crafted", InventoryEventCrafting},
+	{"Unpacked", InventoryEventUnpacked},
+	{"Gift", InventoryEventGift},
+}
+
+var inventoryHistoryDateLayouts = []string{
+	"Jan 2, 2006 3:04pm",
+	"2 Jan, 2006 3:04pm",
+}
+
+type InventoryHistoryCursor struct {
+	Time     int64  `json:"time"`
+	TimeFrac int64  `json:"time_frac"`
+	S        string `json:"s"`
+}
+
+type InventoryHistoryItem struct {
+	AppID      uint32
+	ContextID  uint64
+	ClassID    uint64
+	InstanceID uint64
+	Name       string
+	Desc       *EconItemDesc // May be nil
+}
+
+type InventoryHistoryEvent struct {
+	Time           time.Time // Zero if it couldn't be parsed
+	Type           int       // One of InventoryEvent*
+	Description    string
+	PartnerName    string
+	PartnerURL     string
+	PartnerSteamID SteamID // Only set when PartnerURL has it
+	Gained         []*InventoryHistoryItem
+	Lost           []*InventoryHistoryItem
+}
+
+type InventoryHistoryPage struct {
+	Events []*InventoryHistoryEvent
+	// Cursor is where the next page starts, nil on the last page.
+	Cursor *InventoryHistoryCursor
+}
+
+// GetInventoryHistory fetches a page of the inventory history, newest first,
+// @cursor is nil for the first page and Cursor of the previous page after.
+// Event types are only recognized in english, other languages give InventoryEventOther.
+func (session *Session) GetInventoryHistory(cursor *InventoryHistoryCursor) (*InventoryHistoryPage, error) {
+	type Response struct {
+		Success      bool                                `json:"success"`
+		HTML         string                              `json:"html"`
+		Num          int                                 `json:"num"`
+		Descriptions map[string]map[string]*EconItemDesc `json:"descriptions"`
+		Cursor       *InventoryHistoryCursor             `json:"cursor"`
+	}
+
+	params := url.Values{
+		"ajax":      {"1"},
+		"sessionid": {session.sessionID},
+		"l":         {session.language},
+	}
+	if cursor != nil {
+		params.Set("cursor[time]", strconv.FormatInt(cursor.Time, 10))
+		params.Set("cursor[time_frac]", strconv.FormatInt(cursor.TimeFrac, 10))
+		params.Set("cursor[s]", cursor.S)
+	}
+
+	resp, err := session.client.Get("https://steamcommunity.com/profiles/" + session.oauth.SteamID.ToString() + "/inventoryhistory/?" + params.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	response := Response{}
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, ErrCannotLoadInventoryHistory
+	}
+
+	events, err := parseInventoryHistory(response.HTML, response.Descriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InventoryHistoryPage{
+		Events: events,
+		Cursor: response.Cursor,
+	}, nil
+}
+
+// GetInventoryHistorySince pages through the history until an event older than @since.
+func (session *Session) GetInventoryHistorySince(since time.Time) ([]*InventoryHistoryEvent, error) {
+	events := []*InventoryHistoryEvent{}
+	var cursor *InventoryHistoryCursor
+	for {
+		page, err := session.GetInventoryHistory(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range page.Events {
+			if !event.Time.IsZero() && event.Time.Before(since) {
+				return events, nil
+			}
+
+			events = append(events, event)
+		}
+
+		if page.Cursor == nil || len(page.Events) == 0 {
+			return events, nil
+		}
+
+		cursor = page.Cursor
+	}
+}
+
+func parseInventoryHistory(page string, descriptions map[string]map[string]*EconItemDesc) ([]*InventoryHistoryEvent, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*InventoryHistoryEvent{}
+	doc.Find(".tradehistoryrow").Each(func(i int, row *goquery.Selection) {
+		event := &InventoryHistoryEvent{
+			Gained: []*InventoryHistoryItem{},
+			Lost:   []*InventoryHistoryItem{},
+		}
+
+		date := row.Find(".tradehistory_date")
+		timestamp := strings.TrimSpace(date.Find(".tradehistory_timestamp").Text())
+		day := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(date.Text()), timestamp))
+		for _, layout := range inventoryHistoryDateLayouts {
+			if t, err := time.Parse(layout, day+" "+timestamp); err == nil {
+				event.Time = t
+				break
+			}
+		}
+
+		description := row.Find(".tradehistory_event_description")
+		event.Description = strings.Join(strings.Fields(description.Text()), " ")
+		for _, known := range inventoryEventPrefixes {
+			if strings.HasPrefix(event.Description, known.prefix) {
+				event.Type = known.eventType
+				break
+			}
+		}
+
+		if partner := description.Find("a").First(); partner.Length() != 0 {
+			event.PartnerName = strings.TrimSpace(partner.Text())
+			event.PartnerURL, _ = partner.Attr("href")
+			if i := strings.Index(event.PartnerURL, "/profiles/"); i != -1 {
+				id := strings.Trim(event.PartnerURL[i+len("/profiles/"):], "/")
+				if sid, err := strconv.ParseUint(id, 10, 64); err == nil {
+					event.PartnerSteamID = SteamID(sid)
+				}
+			}
+		}
+
+		row.Find(".tradehistory_items").Each(func(i int, group *goquery.Selection) {
+			gained := strings.TrimSpace(group.Find(".tradehistory_items_plusminus").Text()) == "+"
+			group.Find(".history_item").Each(func(i int, s *goquery.Selection) {
+				item := &InventoryHistoryItem{
+					Name: strings.TrimSpace(s.Find(".history_item_name").Text()),
+				}
+
+				appID, _ := strconv.ParseUint(s.AttrOr("data-appid", ""), 10, 32)
+				item.AppID = uint32(appID)
+				item.ContextID, _ = strconv.ParseUint(s.AttrOr("data-contextid", ""), 10, 64)
+				item.ClassID, _ = strconv.ParseUint(s.AttrOr("data-classid", ""), 10, 64)
+				item.InstanceID, _ = strconv.ParseUint(s.AttrOr("data-instanceid", "0"), 10, 64)
+
+				if descs, ok := descriptions[strconv.FormatUint(appID, 10)]; ok {
+					item.Desc = descs[fmt.Sprintf("%d_%d", item.ClassID, item.InstanceID)]
+				}
+
+				if gained {
+					event.Gained = append(event.Gained, item)
+				} else {
+					event.Lost = append(event.Lost, item)
+				}
+			})
+		})
+
+		events = append(events, event)
+	})
+
+	return events, nil
+}
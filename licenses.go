@@ -0,0 +1,200 @@
+package steam
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var removeLicenseRegexp = regexp.MustCompile(`RemoveFreeLicense\(\s*([0-9]+)`)
+
+// License is a row of the licenses page, SubID is only known for the free
+// licenses (the ones that can be removed), see GetStoreUserData for all of them.
+type License struct {
+	Date        string
+	Name        string
+	Acquisition string
+	SubID       uint64
+}
+
+// StoreUserData is what the store knows we own.
+type StoreUserData struct {
+	OwnedPackages []uint64 `json:"rgOwnedPackages"`
+	OwnedApps     []uint32 `json:"rgOwnedApps"`
+	Wishlist      []uint32 `json:"rgWishlist"`
+}
+
+func (data *StoreUserData) OwnsApp(appID uint32) bool {
+	for _, id := range data.OwnedApps {
+		if id == appID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (data *StoreUserData) OwnsPackage(subID uint64) bool {
+	for _, id := range data.OwnedPackages {
+		if id == subID {
+			return true
+		}
+	}
+
+	return false
+}
+
+type PackageApp struct {
+	ID   uint32 `json:"id"`
+	Name string `json:"name"`
+}
+
+type PackageDetails struct {
+	SubID uint64        `json:"-"`
+	Name  string        `json:"name"`
+	Apps  []*PackageApp `json:"apps"`
+	Price struct {
+		Currency string `json:"currency"`
+		Initial  int64  `json:"initial"`
+		Final    int64  `json:"final"`
+	} `json:"price"`
+}
+
+// GetLicenses scrapes the licenses page of the account.  This is a store request,
+// see PrepareForSteamStore.
+func (session *Session) GetLicenses() ([]*License, error) {
+	resp, err := session.client.Get("https://store.steampowered.com/account/licenses/?l=" + session.language)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	licenses := []*License{}
+	doc.Find(".account_table tr").Each(func(i int, s *goquery.Selection) {
+		date := s.Find("td.license_date_col")
+		if date.Length() == 0 {
+			return
+		}
+
+		name := date.Next()
+		license := &License{
+			Date:        strings.TrimSpace(date.Text()),
+			Acquisition: strings.TrimSpace(s.Find("td.license_acquisition_col").Text()),
+		}
+
+		if html, err := name.Html(); err == nil {
+			if m := removeLicenseRegexp.FindStringSubmatch(html); m != nil {
+				license.SubID, _ = strconv.ParseUint(m[1], 10, 64)
+			}
+		}
+
+		// The remove link is in the name cell too.
+		name.Find(".free_license_remove_link").Remove()
+		license.Name = strings.TrimSpace(name.Text())
+
+		licenses = append(licenses, license)
+	})
+
+	return licenses, nil
+}
+
+// GetStoreUserData returns the packages and apps we own.  This is a store request,
+// see PrepareForSteamStore.
+func (session *Session) GetStoreUserData() (*StoreUserData, error) {
+	resp, err := session.client.Get("https://store.steampowered.com/dynamicstore/userdata/")
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	data := &StoreUserData{}
+	if err = json.NewDecoder(resp.Body).Decode(data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (session *Session) getPackageDetails(subID uint64) (*PackageDetails, error) {
+	params := url.Values{
+		"packageids": {strconv.FormatUint(subID, 10)},
+		"l":          {session.language},
+	}
+	if len(session.country) != 0 {
+		params.Set("cc", session.country)
+	}
+
+	resp, err := session.client.Get("https://store.steampowered.com/api/packagedetails/?" + params.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response map[string]struct {
+		Success bool            `json:"success"`
+		Data    *PackageDetails `json:"data"`
+	}
+
+	var response Response
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	entry := response[strconv.FormatUint(subID, 10)]
+	if !entry.Success || entry.Data == nil {
+		return nil, nil
+	}
+
+	entry.Data.SubID = subID
+	return entry.Data, nil
+}
+
+// GetPackageDetails resolves packages to their apps, one request per package since
+// the store only details one at a time.  Packages the store doesn't know are left out.
+func (session *Session) GetPackageDetails(subIDs []uint64) (map[uint64]*PackageDetails, error) {
+	details := make(map[uint64]*PackageDetails, len(subIDs))
+	for _, subID := range subIDs {
+		pkg, err := session.getPackageDetails(subID)
+		if err != nil {
+			return nil, err
+		}
+
+		if pkg != nil {
+			details[subID] = pkg
+		}
+	}
+
+	return details, nil
+}
@@ -0,0 +1,100 @@
+package steam
+
+import (
+	"context"
+	"sync"
+)
+
+// lifecycle is embedded by the background components (ChatPoller, EventDispatcher,
+// TradeOfferManager, PriceWatcher, PlayerCountPoller, SessionPool) for their Start(ctx), Stop and Wait.
+// Their loop is given a channel closed on Stop or once the context is done, it is
+// expected to finish what it's doing (e.g. a request in flight) and return, Wait
+// returns after that.
+type lifecycle struct {
+	stop    chan struct{}
+	done    chan struct{}
+	running bool
+	stopped bool
+	mutex   sync.Mutex
+}
+
+// start calls @prepare (which may be nil) and runs @run in the background, unless
+// it's already running in which case @errStarted is returned.  If the previous run
+// was stopped but hasn't returned yet, it waits for it first.
+func (l *lifecycle) start(ctx context.Context, errStarted error, prepare func() error, run func(stop chan struct{})) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for l.running {
+		if !l.stopped {
+			return errStarted
+		}
+
+		done := l.done
+		l.mutex.Unlock()
+		<-done
+		l.mutex.Lock()
+	}
+
+	if prepare != nil {
+		if err := prepare(); err != nil {
+			return err
+		}
+	}
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	l.stop, l.done, l.running, l.stopped = stop, done, true, false
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			l.stopRun(stop)
+		case <-done:
+		}
+	}()
+
+	go func() {
+		defer close(done)
+		defer func() {
+			l.mutex.Lock()
+			l.running = false
+			l.mutex.Unlock()
+		}()
+
+		run(stop)
+	}()
+	return nil
+}
+
+// stopRun stops the run of @stop, if it's still the current one, it keeps
+// running until the loop returned.
+func (l *lifecycle) stopRun(stop chan struct{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.running && !l.stopped && l.stop == stop {
+		close(l.stop)
+		l.stopped = true
+	}
+}
+
+// Stop asks the loop to stop, without waiting for it, see Wait.
+func (l *lifecycle) Stop() {
+	l.mutex.Lock()
+	stop := l.stop
+	l.mutex.Unlock()
+
+	l.stopRun(stop)
+}
+
+// Wait blocks until the loop returned, either after Stop or once the context
+// given to Start is done.  It returns immediately if it was never started.
+func (l *lifecycle) Wait() {
+	l.mutex.Lock()
+	done := l.done
+	l.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
@@ -0,0 +1,58 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLifecycleRestartWaitsForPreviousRun(t *testing.T) {
+	errStarted := errors.New("started")
+	release := make(chan struct{})
+	runs := make(chan int, 2)
+
+	var l lifecycle
+	run := func(n int) func(stop chan struct{}) {
+		return func(stop chan struct{}) {
+			runs <- n
+			<-stop
+			if n == 1 {
+				<-release
+			}
+		}
+	}
+
+	if err := l.start(context.Background(), errStarted, nil, run(1)); err != nil {
+		t.Fatal(err)
+	}
+	<-runs
+
+	if err := l.start(context.Background(), errStarted, nil, run(2)); err != errStarted {
+		t.Fatalf("start while running = %v, want errStarted", err)
+	}
+
+	l.Stop()
+	started := make(chan error, 1)
+	go func() {
+		started <- l.start(context.Background(), errStarted, nil, run(2))
+	}()
+
+	select {
+	case n := <-runs:
+		t.Fatalf("run %d started before the first one returned", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-started; err != nil {
+		t.Fatal(err)
+	}
+
+	if n := <-runs; n != 2 {
+		t.Fatalf("run %d, want 2", n)
+	}
+
+	l.Stop()
+	l.Wait()
+}
@@ -1,7 +1,7 @@
 package steam
 
 import (
-	"crypto/md5"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/base64"
@@ -16,6 +16,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 type LoginResponse struct {
@@ -52,6 +54,28 @@ type Session struct {
 	umqID       string
 	chatMessage int
 	language    string
+	country     string
+	appList     appListCache
+	limiter     *RateLimiter
+	timeouts    endpointTimeouts
+	breaker     *CircuitBreaker
+	concurrency *concurrencyLimits
+	debugSink   DebugSink
+
+	responseObserver ResponseObserver
+
+	loginThrottle *LoginThrottle
+	achievements  achievementCache
+
+	dedupeGets         bool
+	validators         ValidatorCache
+	getFlight          singleflight.Group
+	priceHistoryFlight singleflight.Group
+
+	keepRawBody    bool
+	strictDecoding bool
+
+	saleEvents bool
 }
 
 const (
@@ -122,6 +146,12 @@ func (session *Session) proceedDirectLogin(response *LoginResponse, accountName,
 		return err
 	}
 
+	if throttle := session.loginThrottle; throttle != nil {
+		if err := throttle.record(accountName, !loginSession.Success); err != nil {
+			return err
+		}
+	}
+
 	if !loginSession.Success {
 		if loginSession.RequiresTwoFactor {
 			return ErrNeedTwoFactor
@@ -152,11 +182,9 @@ func (session *Session) proceedDirectLogin(response *LoginResponse, accountName,
 		}
 	}
 
-	sum := md5.Sum([]byte(accountName + password))
-	session.deviceID = fmt.Sprintf(
-		"android:%x-%x-%x-%x-%x",
-		sum[:2], sum[2:4], sum[4:6], sum[6:8], sum[8:10],
-	)
+	if len(session.deviceID) == 0 {
+		session.deviceID = GenerateDeviceID(session.oauth.SteamID)
+	}
 
 	session.client.Jar.SetCookies(
 		url,
@@ -221,6 +249,12 @@ func (session *Session) makeLoginRequest(accountName, password string) (*LoginRe
 // Note: You can provide an empty two factor code if two factor authentication is not
 // enabled on the account provided.
 func (session *Session) LoginTwoFactorCode(accountName, password, twoFactorCode string) error {
+	if throttle := session.loginThrottle; throttle != nil {
+		if err := throttle.Check(accountName); err != nil {
+			return err
+		}
+	}
+
 	response, err := session.makeLoginRequest(accountName, password)
 	if err != nil {
 		return err
@@ -233,6 +267,12 @@ func (session *Session) LoginTwoFactorCode(accountName, password, twoFactorCode
 // to do the actual login, this provides a better chance that the code generated will work
 // because of the slowness of the API.
 func (session *Session) Login(accountName, password, sharedSecret string, timeOffset time.Duration) error {
+	if throttle := session.loginThrottle; throttle != nil {
+		if err := throttle.Check(accountName); err != nil {
+			return err
+		}
+	}
+
 	response, err := session.makeLoginRequest(accountName, password)
 	if err != nil {
 		return err
@@ -252,22 +292,102 @@ func (session *Session) GetSteamID() SteamID {
 	return session.oauth.SteamID
 }
 
+// IsLoggedIn checks that the session cookies are still valid and returns the
+// SteamID they are logged in as.  It's cheap enough to be used as a health check.
+func (session *Session) IsLoggedIn(ctx context.Context) (SteamID, bool, error) {
+	type Response struct {
+		LoggedIn bool    `json:"logged_in"`
+		SteamID  SteamID `json:"steamid,string"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://steamcommunity.com/chat/clientjstoken", nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resp, err := session.client.Do(req.WithContext(ctx))
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	response := Response{}
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return 0, false, err
+	}
+
+	if !response.LoggedIn {
+		return 0, false, nil
+	}
+
+	return response.SteamID, true, nil
+}
+
+// SetLanguage sets the language (e.g. "german") item names, descriptions and
+// other texts are returned in, by the endpoints taking one.  The language cookie
+// of a logged in session is changed too, for the pages that use it instead.
 func (session *Session) SetLanguage(lang string) {
 	session.language = lang
+
+	if session.client.Jar != nil {
+		for _, rawURL := range sessionStateURLs {
+			u, _ := url.Parse(rawURL)
+			session.client.Jar.SetCookies(u, []*http.Cookie{{Name: "Steam_Language", Value: lang}})
+		}
+	}
 }
 
-func NewSessionWithAPIKey(apiKey string) *Session {
-	return &Session{
-		client:   &http.Client{},
-		apiKey:   apiKey,
-		language: "english",
+func (session *Session) GetLanguage() string {
+	return session.language
+}
+
+// SetCountry sets the country code (e.g. "DE") the market endpoints give prices
+// and availability for, empty lets Steam pick it from the account or IP.
+func (session *Session) SetCountry(country string) {
+	session.country = country
+}
+
+func (session *Session) GetCountry() string {
+	return session.country
+}
+
+// withCountry adds the session country to @values when there is one.
+func (session *Session) withCountry(values url.Values) url.Values {
+	if len(session.country) != 0 {
+		values.Set("country", session.country)
 	}
+
+	return values
+}
+
+// SetDeviceID overrides the device id used for confirmations, this is needed
+// if the authenticator was set up with a device id derived differently, e.g.
+// GenerateLegacyDeviceID for those enrolled by earlier versions of this package.
+// Login only sets GenerateDeviceID when no device id was set.
+func (session *Session) SetDeviceID(deviceID string) {
+	session.deviceID = deviceID
+}
+
+func (session *Session) GetDeviceID() string {
+	return session.deviceID
+}
+
+func NewSessionWithAPIKey(apiKey string) *Session {
+	return NewSession(&http.Client{}, apiKey)
 }
 
 func NewSession(client *http.Client, apiKey string) *Session {
-	return &Session{
-		client:   client,
+	session := &Session{
 		apiKey:   apiKey,
 		language: "english",
 	}
+	session.setClient(client)
+	return session
 }
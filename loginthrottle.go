@@ -0,0 +1,146 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrTooManyLoginAttempts = errors.New("too many login attempts")
+
+// How many attempts the memory store keeps per account.
+const maxStoredLoginAttempts = 50
+
+// LoginThrottleError is returned instead of logging in, Wait is how long until
+// the throttle lets the next attempt through.
+type LoginThrottleError struct {
+	Account string
+	Wait    time.Duration
+}
+
+func (err *LoginThrottleError) Error() string {
+	return fmt.Sprintf("%v for %s, retry in %v", ErrTooManyLoginAttempts, err.Account, err.Wait)
+}
+
+func (err *LoginThrottleError) Unwrap() error {
+	return ErrTooManyLoginAttempts
+}
+
+// LoginAttempt is a login Steam answered, Failed if it refused it (wrong
+// password or two factor code, captcha...).
+type LoginAttempt struct {
+	Time   time.Time
+	Failed bool
+}
+
+// LoginAttemptStore keeps the login attempts by account name, so that they survive
+// restarts (a crash loop logging in is the usual way to get locked out).
+type LoginAttemptStore interface {
+	// LoginAttempts returns the attempts of @account, oldest first.
+	LoginAttempts(account string) ([]LoginAttempt, error)
+	AddLoginAttempt(account string, attempt LoginAttempt) error
+}
+
+type memoryLoginAttemptStore struct {
+	attempts map[string][]LoginAttempt
+	mutex    sync.Mutex
+}
+
+func NewMemoryLoginAttemptStore() LoginAttemptStore {
+	return &memoryLoginAttemptStore{attempts: make(map[string][]LoginAttempt)}
+}
+
+func (store *memoryLoginAttemptStore) LoginAttempts(account string) ([]LoginAttempt, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return append([]LoginAttempt(nil), store.attempts[account]...), nil
+}
+
+func (store *memoryLoginAttemptStore) AddLoginAttempt(account string, attempt LoginAttempt) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	attempts := append(store.attempts[account], attempt)
+	if len(attempts) > maxStoredLoginAttempts {
+		attempts = attempts[len(attempts)-maxStoredLoginAttempts:]
+	}
+	store.attempts[account] = attempts
+	return nil
+}
+
+// LoginThrottle refuses logins after MaxFailures failed attempts within Window,
+// and attempts less than MinInterval after the previous one.
+type LoginThrottle struct {
+	Store       LoginAttemptStore
+	MaxFailures int
+	Window      time.Duration
+	MinInterval time.Duration
+	Clock       Clock
+}
+
+// NewLoginThrottle stays below what is known to get accounts rate limited by Steam,
+// @store may be nil to keep the attempts in memory.
+func NewLoginThrottle(store LoginAttemptStore) *LoginThrottle {
+	if store == nil {
+		store = NewMemoryLoginAttemptStore()
+	}
+
+	return &LoginThrottle{
+		Store:       store,
+		MaxFailures: 5,
+		Window:      time.Hour,
+		MinInterval: 10 * time.Second,
+		Clock:       SystemClock,
+	}
+}
+
+// Check returns a *LoginThrottleError if @account shouldn't try logging in now.
+func (throttle *LoginThrottle) Check(account string) error {
+	attempts, err := throttle.Store.LoginAttempts(account)
+	if err != nil {
+		return err
+	}
+
+	if len(attempts) == 0 {
+		return nil
+	}
+
+	now := clockOrSystem(throttle.Clock).Now()
+	wait := attempts[len(attempts)-1].Time.Add(throttle.MinInterval).Sub(now)
+
+	failures := []time.Time{}
+	for _, attempt := range attempts {
+		if attempt.Failed && now.Sub(attempt.Time) < throttle.Window {
+			failures = append(failures, attempt.Time)
+		}
+	}
+
+	if throttle.MaxFailures > 0 && len(failures) >= throttle.MaxFailures {
+		// Wait for enough of them to leave the window.
+		until := failures[len(failures)-throttle.MaxFailures].Add(throttle.Window).Sub(now)
+		if until > wait {
+			wait = until
+		}
+	}
+
+	if wait > 0 {
+		return &LoginThrottleError{Account: account, Wait: wait}
+	}
+
+	return nil
+}
+
+func (throttle *LoginThrottle) record(account string, failed bool) error {
+	return throttle.Store.AddLoginAttempt(account, LoginAttempt{
+		Time:   clockOrSystem(throttle.Clock).Now(),
+		Failed: failed,
+	})
+}
+
+// SetLoginThrottle makes Login and LoginTwoFactorCode check @throttle before logging
+// in and record the attempts, nil disables it.
+func (session *Session) SetLoginThrottle(throttle *LoginThrottle) {
+	session.loginThrottle = throttle
+}
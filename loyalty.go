@@ -0,0 +1,102 @@
+package steam
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	apiLoyaltyGetSummary        = "https://api.steampowered.com/ILoyaltyRewardsService/GetSummary/v1/?"
+	apiLoyaltyQueryRewardItems  = "https://api.steampowered.com/ILoyaltyRewardsService/QueryRewardItems/v1/?"
+	apiLoyaltyRedeemPoints      = "https://api.steampowered.com/ILoyaltyRewardsService/RedeemPoints/v1/"
+	loyaltyRewardItemsPageLimit = 100
+)
+
+type LoyaltySummary struct {
+	Points       uint64 `json:"points,string"`
+	PointsEarned uint64 `json:"points_earned,string"`
+	PointsSpent  uint64 `json:"points_spent,string"`
+}
+
+// LoyaltyReward is an item of the points shop, Type is the community item type
+// (e.g. 3 for profile backgrounds, 4 for emoticons).
+type LoyaltyReward struct {
+	AppID               uint32 `json:"appid"`
+	DefID               uint32 `json:"defid"`
+	Type                int    `json:"type"`
+	CommunityItemClass  uint32 `json:"community_item_class"`
+	PointCost           uint64 `json:"point_cost,string"`
+	TimeCreated         int64  `json:"timestamp_created"`
+	TimeUpdated         int64  `json:"timestamp_updated"`
+	Active              bool   `json:"active"`
+	InternalDescription string `json:"internal_description"`
+}
+
+type LoyaltyRewardsPage struct {
+	Rewards    []*LoyaltyReward `json:"definitions"`
+	TotalCount int              `json:"total_count"`
+	Count      int              `json:"count"`
+	NextCursor string           `json:"next_cursor"` // Empty on the last page
+}
+
+// GetLoyaltySummary returns the points balance of @sid.
+func (session *Session) GetLoyaltySummary(sid SteamID) (*LoyaltySummary, error) {
+	type Summary struct {
+		Summary *LoyaltySummary `json:"summary"`
+	}
+
+	resp, err := session.client.Get(apiLoyaltyGetSummary + url.Values{
+		"access_token": {session.oauth.Token},
+		"steamid":      {sid.ToString()},
+	}.Encode())
+
+	summary := &Summary{}
+	if err = session.decodeServiceResponse(resp, err, summary); err != nil {
+		return nil, err
+	}
+
+	if summary.Summary == nil {
+		return &LoyaltySummary{}, nil
+	}
+
+	return summary.Summary, nil
+}
+
+// QueryLoyaltyRewards lists the points shop items of @appIDs (all if empty), @cursor
+// is empty for the first page and NextCursor of the previous page after.
+func (session *Session) QueryLoyaltyRewards(appIDs []uint32, cursor string, count int) (*LoyaltyRewardsPage, error) {
+	if count <= 0 || count > loyaltyRewardItemsPageLimit {
+		count = loyaltyRewardItemsPageLimit
+	}
+
+	params := url.Values{
+		"access_token": {session.oauth.Token},
+		"count":        {strconv.Itoa(count)},
+		"language":     {session.language},
+	}
+	if len(cursor) != 0 {
+		params.Set("cursor", cursor)
+	}
+	for i, appID := range appIDs {
+		params.Set(fmt.Sprintf("appids[%d]", i), strconv.FormatUint(uint64(appID), 10))
+	}
+
+	resp, err := session.client.Get(apiLoyaltyQueryRewardItems + params.Encode())
+
+	page := &LoyaltyRewardsPage{}
+	if err = session.decodeServiceResponse(resp, err, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// RedeemLoyaltyPoints buys the reward @defID with points.
+func (session *Session) RedeemLoyaltyPoints(defID uint32) error {
+	resp, err := session.client.PostForm(apiLoyaltyRedeemPoints+"?access_token="+url.QueryEscape(session.oauth.Token), url.Values{
+		"defid": {strconv.FormatUint(uint64(defID), 10)},
+	})
+
+	return session.decodeServiceResponse(resp, err, &struct{}{})
+}
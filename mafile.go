@@ -0,0 +1,102 @@
+package steam
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// MaFileSession is the session Steam Desktop Authenticator keeps in maFiles.
+type MaFileSession struct {
+	SessionID        string  `json:"SessionID"`
+	SteamLogin       string  `json:"SteamLogin"`
+	SteamLoginSecure string  `json:"SteamLoginSecure"`
+	WebCookie        string  `json:"WebCookie"`
+	OAuthToken       string  `json:"OAuthToken"`
+	SteamID          SteamID `json:"SteamID"`
+}
+
+// MaFile is the authenticator data as saved by Steam Desktop Authenticator (the
+// unencrypted .maFile files, SDA encryption is not supported: decrypt them in SDA
+// first).  SharedSecret is what GenerateTwoFactorCode takes, IdentitySecret and
+// DeviceID what confirmations need.
+type MaFile struct {
+	SharedSecret   string         `json:"shared_secret"`
+	SerialNumber   string         `json:"serial_number"`
+	RevocationCode string         `json:"revocation_code"`
+	URI            string         `json:"uri"`
+	ServerTime     int64          `json:"server_time"`
+	AccountName    string         `json:"account_name"`
+	TokenGID       string         `json:"token_gid"`
+	IdentitySecret string         `json:"identity_secret"`
+	Secret1        string         `json:"secret_1"`
+	Status         int            `json:"status"`
+	DeviceID       string         `json:"device_id"`
+	FullyEnrolled  bool           `json:"fully_enrolled"`
+	Session        *MaFileSession `json:"Session"`
+}
+
+// NewMaFile exports what EnableTwoFactor returned (@info) as a maFile, so that the
+// authenticator can also be used from SDA.
+func NewMaFile(info *TwoFactorInfo, accountName, deviceID string) *MaFile {
+	return &MaFile{
+		SharedSecret:   info.SharedSecret,
+		SerialNumber:   formatUint(info.SerialNumber),
+		RevocationCode: info.RevocationCode,
+		URI:            info.URI,
+		ServerTime:     int64(info.ServerTime),
+		AccountName:    accountName,
+		TokenGID:       info.TokenGID,
+		IdentitySecret: info.IdentitySecret,
+		Secret1:        info.Secret1,
+		Status:         int(info.Status),
+		DeviceID:       deviceID,
+		FullyEnrolled:  true,
+	}
+}
+
+func ParseMaFile(r io.Reader) (*MaFile, error) {
+	maFile := &MaFile{}
+	if err := json.NewDecoder(r).Decode(maFile); err != nil {
+		return nil, err
+	}
+
+	return maFile, nil
+}
+
+func LoadMaFile(path string) (*MaFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseMaFile(f)
+}
+
+func (maFile *MaFile) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(maFile)
+}
+
+// Save writes the maFile to @path, readable by the owner only since it has the secrets.
+func (maFile *MaFile) Save(path string) error {
+	data, err := json.Marshal(maFile)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// Apply sets the device ID of the maFile on @session, for the confirmations.
+func (maFile *MaFile) Apply(session *Session) {
+	if len(maFile.DeviceID) != 0 {
+		session.SetDeviceID(maFile.DeviceID)
+	}
+}
+
+// TwoFactorCode generates the login code at the unix time @current.
+func (maFile *MaFile) TwoFactorCode(current int64) (string, error) {
+	return GenerateTwoFactorCode(maFile.SharedSecret, current)
+}
@@ -1,9 +1,9 @@
 package steam
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -55,10 +55,17 @@ const (
 )
 
 type MarketItemPriceOverview struct {
+	RawResponse
+
 	Success     bool   `json:"success"`
 	LowestPrice string `json:"lowest_price"`
 	MedianPrice string `json:"median_price"`
 	Volume      string `json:"volume"`
+
+	// Parsed from the strings above, prices in cents, 0 if missing.
+	ParsedLowest uint64 `json:"-"`
+	ParsedMedian uint64 `json:"-"`
+	ParsedVolume uint64 `json:"-"`
 }
 
 type MarketItemPrice struct {
@@ -68,6 +75,8 @@ type MarketItemPrice struct {
 }
 
 type MarketItemResponse struct {
+	RawResponse
+
 	Success     bool        `json:"success"`
 	PricePrefix string      `json:"price_prefix"`
 	PriceSuffix string      `json:"price_suffix"`
@@ -75,6 +84,8 @@ type MarketItemResponse struct {
 }
 
 type MarketItemSearchResponse struct {
+	RawResponse
+
 	Success    bool        `json:"success"`
 	Start      int         `json:"start"`
 	PageSize   int         `json:"pagesize"`
@@ -96,6 +107,8 @@ type MarketSearchItem struct {
 }
 
 type MarketSellResponse struct {
+	RawResponse
+
 	Success                    bool   `json:"success"`
 	RequiresConfirmation       uint32 `json:"requires_confirmation"`
 	MobileConfirmationRequired bool   `json:"needs_mobile_confirmation"`
@@ -104,6 +117,8 @@ type MarketSellResponse struct {
 }
 
 type MarketBuyOrderResponse struct {
+	RawResponse
+
 	ErrCode int    `json:"success"`
 	ErrMsg  string `json:"message"` // Set if ErrCode != 1
 	OrderID uint64 `json:"buy_orderid,string"`
@@ -114,7 +129,7 @@ var (
 	ErrInvalidPriceResponse = errors.New("invalid market pricehistory response")
 )
 
-func (session *Session) GetMarketItemPriceHistory(appID uint64, marketHashName string) ([]*MarketItemPrice, error) {
+func (session *Session) GetMarketItemPriceHistory(appID uint64, marketHashName string) (_ []*MarketItemPrice, err error) {
 	resp, err := session.client.Get("https://steamcommunity.com/market/pricehistory/?" + url.Values{
 		"appid":            {strconv.FormatUint(appID, 10)},
 		"market_hash_name": {marketHashName},
@@ -131,8 +146,11 @@ func (session *Session) GetMarketItemPriceHistory(appID uint64, marketHashName s
 		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
 	}
 
+	sample := newPayloadSample(resp.Body)
+	defer recoverPayload(&err, sample)
+
 	response := MarketItemResponse{}
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeJSON(sample, &response); err != nil {
 		return nil, err
 	}
 
@@ -168,13 +186,18 @@ func (session *Session) GetMarketItemPriceHistory(appID uint64, marketHashName s
 	return items, nil
 }
 
+// GetMarketItemPriceOverview @country may be empty for the session country, see SetCountry.
 func (session *Session) GetMarketItemPriceOverview(appID uint64, country, currencyID, marketHashName string) (*MarketItemPriceOverview, error) {
-	resp, err := session.client.Get("https://steamcommunity.com/market/priceoverview/?" + url.Values{
+	params := session.withCountry(url.Values{
 		"appid":            {strconv.FormatUint(appID, 10)},
-		"country":          {country},
 		"currencyID":       {currencyID},
 		"market_hash_name": {marketHashName},
-	}.Encode())
+	})
+	if len(country) != 0 {
+		params.Set("country", country)
+	}
+
+	resp, err := session.client.Get("https://steamcommunity.com/market/priceoverview/?" + params.Encode())
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -188,20 +211,28 @@ func (session *Session) GetMarketItemPriceOverview(appID uint64, country, curren
 	}
 
 	overview := &MarketItemPriceOverview{}
-	if err = json.NewDecoder(resp.Body).Decode(overview); err != nil {
+	if err = session.decodeJSON(resp.Body, overview); err != nil {
 		return nil, err
 	}
 
+	overview.ParsedLowest, _ = ParsePrice(overview.LowestPrice)
+	overview.ParsedMedian, _ = ParsePrice(overview.MedianPrice)
+	overview.ParsedVolume, _ = strconv.ParseUint(strings.Replace(overview.Volume, ",", "", -1), 10, 64)
 	return overview, nil
 }
 
 func (session *Session) GetMarketItemSearch(appID uint64, searchQuery string, offset int, count int) (*MarketItemSearchResponse, []*MarketSearchItem, error) {
-	resp, err := session.client.Get("https://steamcommunity.com/market/search/render/?norender=1&" + url.Values{
+	return session.searchMarket(session.withCountry(url.Values{
 		"appid":  {strconv.FormatUint(appID, 10)},
 		"query":  {searchQuery},
 		"offset": {strconv.Itoa(offset)},
 		"count":  {strconv.Itoa(count)},
-	}.Encode())
+		"l":      {session.language},
+	}))
+}
+
+func (session *Session) searchMarket(params url.Values) (_ *MarketItemSearchResponse, _ []*MarketSearchItem, err error) {
+	resp, err := session.client.Get("https://steamcommunity.com/market/search/render/?norender=1&" + params.Encode())
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -214,8 +245,11 @@ func (session *Session) GetMarketItemSearch(appID uint64, searchQuery string, of
 		return nil, nil, fmt.Errorf("http error: %d", resp.StatusCode)
 	}
 
+	sample := newPayloadSample(resp.Body)
+	defer recoverPayload(&err, sample)
+
 	response := &MarketItemSearchResponse{}
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeJSON(sample, response); err != nil {
 		return nil, nil, err
 	}
 
@@ -273,7 +307,7 @@ func (session *Session) SellItem(item *InventoryItem, amount, price uint64) (*Ma
 	}
 
 	response := &MarketSellResponse{}
-	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+	if err = session.decodeJSON(resp.Body, response); err != nil {
 		return nil, err
 	}
 
@@ -281,6 +315,11 @@ func (session *Session) SellItem(item *InventoryItem, amount, price uint64) (*Ma
 }
 
 func (session *Session) PlaceBuyOrder(appid uint64, priceTotal float64, quantity uint64, currencyID, marketHashName string) (*MarketBuyOrderResponse, error) {
+	return session.placeBuyOrderCents(appid, uint64(math.Round(priceTotal*100)), quantity, currencyID, marketHashName)
+}
+
+// placeBuyOrderCents is PlaceBuyOrder with @priceTotal in cents.
+func (session *Session) placeBuyOrderCents(appid, priceTotal, quantity uint64, currencyID, marketHashName string) (*MarketBuyOrderResponse, error) {
 	req, err := http.NewRequest(
 		http.MethodPost,
 		"https://steamcommunity.com/market/createbuyorder/",
@@ -288,7 +327,7 @@ func (session *Session) PlaceBuyOrder(appid uint64, priceTotal float64, quantity
 			"appid":            {strconv.FormatUint(appid, 10)},
 			"currency":         {currencyID},
 			"market_hash_name": {marketHashName},
-			"price_total":      {strconv.FormatUint(uint64(priceTotal*100), 10)},
+			"price_total":      {strconv.FormatUint(priceTotal, 10)},
 			"quantity":         {strconv.FormatUint(quantity, 10)},
 			"sessionid":        {session.sessionID},
 		}.Encode()),
@@ -297,14 +336,7 @@ func (session *Session) PlaceBuyOrder(appid uint64, priceTotal float64, quantity
 		return nil, err
 	}
 
-	var referer string
-	referer = strings.Replace(marketHashName, " ", "%20", -1)
-	referer = strings.Replace(referer, "#", "%23", -1)
-
-	req.Header.Add(
-		"Referer",
-		fmt.Sprintf("https://steamcommunity.com/market/listings/%d/%s", appid, referer),
-	)
+	req.Header.Add("Referer", ListingURL(appid, marketHashName))
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := session.client.Do(req)
@@ -317,7 +349,7 @@ func (session *Session) PlaceBuyOrder(appid uint64, priceTotal float64, quantity
 	}
 
 	response := &MarketBuyOrderResponse{}
-	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+	if err = session.decodeJSON(resp.Body, response); err != nil {
 		return nil, err
 	}
 
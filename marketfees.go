@@ -0,0 +1,144 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+var (
+	ErrCannotFindWalletInfo = errors.New("unable to find wallet info")
+	ErrPriceTooLow          = errors.New("price is too low to cover the fees")
+)
+
+type WalletInfo struct {
+	Currency                   uint32  `json:"wallet_currency"`
+	Country                    string  `json:"wallet_country"`
+	State                      string  `json:"wallet_state"`
+	Fee                        uint64  `json:"wallet_fee,string"`
+	FeeMinimum                 uint64  `json:"wallet_fee_minimum,string"`
+	FeePercent                 float64 `json:"wallet_fee_percent,string"`
+	PublisherFeePercentDefault float64 `json:"wallet_publisher_fee_percent_default,string"`
+	FeeBase                    uint64  `json:"wallet_fee_base,string"`
+	Balance                    uint64  `json:"wallet_balance,string"`
+	DelayedBalance             uint64  `json:"wallet_delayed_balance,string"`
+	MaxBalance                 uint64  `json:"wallet_max_balance,string"`
+	TradeMaxBalance            uint64  `json:"wallet_trade_max_balance,string"`
+	Success                    int     `json:"success"`
+}
+
+// MarketFees all in cents, Amount = Received + SteamFee + PublisherFee.
+type MarketFees struct {
+	Amount       uint64
+	Received     uint64
+	SteamFee     uint64
+	PublisherFee uint64
+}
+
+// GetWalletInfo reads the wallet info (balance and market fees) from the market page.
+func (session *Session) GetWalletInfo() (*WalletInfo, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/market/")
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	info := &WalletInfo{}
+	found, err := scriptVariable(resp.Body, "g_rgWalletInfo", info)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrCannotFindWalletInfo
+	}
+
+	return info, nil
+}
+
+// FeesForReceived computes what the buyer pays for the seller to receive @received,
+// @publisherFee is a fraction (e.g. 0.1), see PublisherFeePercentDefault.
+func (info *WalletInfo) FeesForReceived(received uint64, publisherFee float64) *MarketFees {
+	steamFee := uint64(math.Floor(math.Max(float64(received)*info.FeePercent, float64(info.FeeMinimum)))) + info.FeeBase
+
+	var pubFee uint64
+	if publisherFee > 0 {
+		pubFee = uint64(math.Floor(math.Max(float64(received)*publisherFee, 1)))
+	}
+
+	return &MarketFees{
+		Amount:       received + steamFee + pubFee,
+		Received:     received,
+		SteamFee:     steamFee,
+		PublisherFee: pubFee,
+	}
+}
+
+// FeesForAmount is the reverse of FeesForReceived, it computes what the seller receives
+// when the buyer pays @amount.  This is the same as what the market page does, the
+// estimate is adjusted a few times because fees are rounded down.
+func (info *WalletInfo) FeesForAmount(amount uint64, publisherFee float64) (*MarketFees, error) {
+	if amount <= info.FeeBase {
+		return nil, ErrPriceTooLow
+	}
+
+	estimate := int64(float64(amount-info.FeeBase) / (info.FeePercent + publisherFee + 1))
+	feesFor := func(received int64) *MarketFees {
+		if received < 0 {
+			received = 0
+		}
+
+		return info.FeesForReceived(uint64(received), publisherFee)
+	}
+
+	undershot := false
+	fees := feesFor(estimate)
+	for iterations := 0; fees.Amount != amount && iterations < 10; iterations++ {
+		if fees.Amount > amount {
+			if undershot {
+				// Can't hit it exactly, give the difference to Steam like the market page does.
+				fees = feesFor(estimate - 1)
+				fees.SteamFee += amount - fees.Amount
+				fees.Amount = amount
+				break
+			}
+
+			estimate--
+		} else {
+			undershot = true
+			estimate++
+		}
+
+		fees = feesFor(estimate)
+	}
+
+	if fees.Received == 0 {
+		return nil, ErrPriceTooLow
+	}
+
+	return fees, nil
+}
+
+// SellItemForBuyerPrice lists @item so that buyers pay @buyerPays (in cents, per item)
+// using the default publisher fee, SellItem on the other hand takes what we receive.
+func (session *Session) SellItemForBuyerPrice(item *InventoryItem, amount, buyerPays uint64) (*MarketSellResponse, error) {
+	info, err := session.GetWalletInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := info.FeesForAmount(buyerPays, info.PublisherFeePercentDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	return session.SellItem(item, amount, fees.Received)
+}
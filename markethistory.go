@@ -0,0 +1,195 @@
+package steam
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// MarketHistoryEvent.Type values.
+const (
+	MarketEventListingCreated   = 1
+	MarketEventListingCancelled = 2
+	MarketEventListingSold      = 3
+	MarketEventListingPurchased = 4
+)
+
+var (
+	ErrCannotLoadMarketHistory = errors.New("unable to load market history at this time")
+	ErrFeesMismatch            = errors.New("fees do not add up to the prices shown")
+)
+
+// MarketPurchase is a sale or purchase of the market history, amounts are in cents
+// of CurrencyID (the buyer's) except ReceivedAmount which is in ReceivedCurrencyID
+// (the seller's).  PaidAmount is what the seller gets and PaidFee is SteamFee +
+// PublisherFee.
+type MarketPurchase struct {
+	ListingID           uint64              `json:"listingid,string"`
+	PurchaseID          uint64              `json:"purchaseid,string"`
+	TimeSold            int64               `json:"time_sold"`
+	Purchaser           uint64              `json:"steamid_purchaser,string"`
+	Failed              int                 `json:"failed"`
+	Asset               *MarketListingAsset `json:"asset"`
+	PaidAmount          uint64              `json:"paid_amount"`
+	PaidFee             uint64              `json:"paid_fee"`
+	CurrencyID          uint32              `json:"currencyid,string"`
+	SteamFee            uint64              `json:"steam_fee"`
+	PublisherFee        uint64              `json:"publisher_fee"`
+	PublisherFeePercent float64             `json:"publisher_fee_percent,string"`
+	PublisherFeeApp     uint32              `json:"publisher_fee_app"`
+	ReceivedAmount      uint64              `json:"received_amount"`
+	ReceivedCurrencyID  uint32              `json:"received_currencyid,string"`
+}
+
+// Fees of the purchase in CurrencyID.
+func (purchase *MarketPurchase) Fees() *MarketFees {
+	return &MarketFees{
+		Amount:       purchase.PaidAmount + purchase.SteamFee + purchase.PublisherFee,
+		Received:     purchase.PaidAmount,
+		SteamFee:     purchase.SteamFee,
+		PublisherFee: purchase.PublisherFee,
+	}
+}
+
+// MarketHistoryEvent Listing and Purchase are set when the history has them,
+// Purchase only for sold and purchased events.
+type MarketHistoryEvent struct {
+	ListingID  uint64 `json:"listingid,string"`
+	PurchaseID uint64 `json:"purchaseid,string"`
+	Type       int    `json:"event_type"`
+	Time       int64  `json:"time_event"`
+	Actor      uint64 `json:"steamid_actor,string"`
+
+	Listing  *MarketListing  `json:"-"`
+	Purchase *MarketPurchase `json:"-"`
+}
+
+type MarketHistoryResponse struct {
+	RawResponse
+
+	Success    bool                  `json:"success"`
+	PageSize   int                   `json:"pagesize"`
+	TotalCount int                   `json:"total_count"`
+	Start      int                   `json:"start"`
+	Events     []*MarketHistoryEvent `json:"events"`
+	// Steam sends empty arrays instead of empty objects, see decodeObject.
+	Assets    json.RawMessage `json:"assets"`
+	Listings  json.RawMessage `json:"listings"`
+	Purchases json.RawMessage `json:"purchases"`
+}
+
+// decodeObject decodes @data into @v unless it's not an object (e.g. []).
+func decodeObject(data json.RawMessage, v interface{}) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func (response *MarketHistoryResponse) link() error {
+	var assets map[string]map[string]map[string]*EconItemDesc
+	if err := decodeObject(response.Assets, &assets); err != nil {
+		return err
+	}
+
+	var listings map[string]*MarketListing
+	if err := decodeObject(response.Listings, &listings); err != nil {
+		return err
+	}
+
+	var purchases map[string]*MarketPurchase
+	if err := decodeObject(response.Purchases, &purchases); err != nil {
+		return err
+	}
+
+	name := func(asset *MarketListingAsset) {
+		if asset == nil || len(asset.MarketHashName) != 0 {
+			return
+		}
+
+		app := assets[strconv.FormatUint(uint64(asset.AppID), 10)]
+		if desc := app[strconv.FormatUint(asset.ContextID, 10)][strconv.FormatUint(asset.AssetID, 10)]; desc != nil {
+			asset.MarketHashName = desc.MarketHashName
+		}
+	}
+
+	for _, event := range response.Events {
+		listingID := strconv.FormatUint(event.ListingID, 10)
+		if event.Listing = listings[listingID]; event.Listing != nil {
+			name(event.Listing.Asset)
+		}
+
+		if event.PurchaseID != 0 {
+			if event.Purchase = purchases[listingID+"_"+strconv.FormatUint(event.PurchaseID, 10)]; event.Purchase != nil {
+				name(event.Purchase.Asset)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetMarketHistory returns a page of our market history, newest first, @count is
+// capped at 500 by Steam.
+func (session *Session) GetMarketHistory(start, count int) (*MarketHistoryResponse, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/market/myhistory/render/?" + url.Values{
+		"norender": {"1"},
+		"start":    {strconv.Itoa(start)},
+		"count":    {strconv.Itoa(count)},
+		"l":        {session.language},
+	}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	response := &MarketHistoryResponse{}
+	if err = session.decodeJSON(resp.Body, response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, ErrCannotLoadMarketHistory
+	}
+
+	if err = response.link(); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ListingFees splits the prices of a listing confirmation into the fees of @info,
+// with @publisherFee as a fraction (see WalletInfo.PublisherFeePercentDefault).
+// ErrFeesMismatch is returned when they don't give what the page shows, e.g. with
+// a different publisher fee.
+func (details *ConfirmationDetails) ListingFees(info *WalletInfo, publisherFee float64) (*MarketFees, error) {
+	buyerPays, err := ParsePrice(details.BuyerPays)
+	if err != nil {
+		return nil, err
+	}
+
+	received, err := ParsePrice(details.YouReceive)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := info.FeesForReceived(received, publisherFee)
+	if fees.Amount != buyerPays {
+		return nil, ErrFeesMismatch
+	}
+
+	return fees, nil
+}
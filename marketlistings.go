@@ -0,0 +1,107 @@
+package steam
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// ErrCannotLoadMarketListings is for the public listings of an item, see
+// ErrCannotLoadListings for our own.
+var ErrCannotLoadMarketListings = errors.New("unable to load the item listings (market/listings render) at this time")
+
+// MarketListingInfo is a listing of the item page, Price and Fee are in the
+// seller's currency (CurrencyID, which is 2000 + the Currency* ID), the converted
+// ones in the currency asked for.
+type MarketListingInfo struct {
+	ID                  uint64              `json:"listingid,string"`
+	Price               uint64              `json:"price"`
+	Fee                 uint64              `json:"fee"`
+	CurrencyID          uint32              `json:"currencyid"`
+	ConvertedPrice      uint64              `json:"converted_price"`
+	ConvertedFee        uint64              `json:"converted_fee"`
+	ConvertedCurrencyID uint32              `json:"converted_currencyid"`
+	PublisherFeeApp     uint32              `json:"publisher_fee_app"`
+	PublisherFeePercent float64             `json:"publisher_fee_percent,string"`
+	Asset               *MarketListingAsset `json:"asset"`
+}
+
+// BuyerPays is what the listing costs in the currency asked for.
+func (listing *MarketListingInfo) BuyerPays() uint64 {
+	return listing.ConvertedPrice + listing.ConvertedFee
+}
+
+type MarketListingsResponse struct {
+	RawResponse
+
+	Success    bool                 `json:"success"`
+	Start      int                  `json:"start"`
+	PageSize   int                  `json:"pagesize"`
+	TotalCount int                  `json:"total_count"`
+	Listings   []*MarketListingInfo `json:"-"` // Cheapest first
+}
+
+// GetMarketListings fetches the listings of an item page, @country may be empty
+// for the session country, @count is capped at 100 by Steam.
+func (session *Session) GetMarketListings(appID uint64, marketHashName, country, currencyID string, start, count int) (*MarketListingsResponse, error) {
+	type Response struct {
+		MarketListingsResponse
+		ListingInfo json.RawMessage `json:"listinginfo"`
+	}
+
+	params := session.withCountry(url.Values{
+		"start":    {strconv.Itoa(start)},
+		"count":    {strconv.Itoa(count)},
+		"currency": {currencyID},
+		"language": {session.language},
+	})
+	if len(country) != 0 {
+		params.Set("country", country)
+	}
+
+	resp, err := session.client.Get(ListingURL(appID, marketHashName) + "/render/?" + params.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	response := &Response{}
+	if err = session.decodeJSON(resp.Body, response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, ErrCannotLoadMarketListings
+	}
+
+	// Steam sends an empty array instead of an object when there are no listings.
+	listings := map[string]*MarketListingInfo{}
+	if raw := bytes.TrimSpace(response.ListingInfo); len(raw) != 0 && raw[0] == '{' {
+		if err = json.Unmarshal(raw, &listings); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &response.MarketListingsResponse
+	result.Listings = make([]*MarketListingInfo, 0, len(listings))
+	for _, listing := range listings {
+		result.Listings = append(result.Listings, listing)
+	}
+
+	sort.Slice(result.Listings, func(i, j int) bool {
+		return result.Listings[i].BuyerPays() < result.Listings[j].BuyerPays()
+	})
+	return result, nil
+}
@@ -0,0 +1,276 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ListingStateNotFound = iota
+	ListingStateActive
+	ListingStateToConfirm
+	ListingStateOnHold
+)
+
+// Maximum count mylistings accepts per page.
+const myListingsPageSize = 100
+
+var (
+	ErrCannotLoadListings         = errors.New("unable to load market listings at this time")
+	ErrListingConfirmationTimeout = errors.New("timed out waiting for listing confirmation")
+	ErrListingNotFound            = errors.New("no listing found for the asset")
+)
+
+type MarketListing struct {
+	ID                  uint64              `json:"listingid,string"`
+	TimeCreated         int64               `json:"time_created"`
+	Asset               *MarketListingAsset `json:"asset"`
+	Price               uint64              `json:"price"`
+	OriginalPrice       uint64              `json:"original_price"`
+	Fee                 uint64              `json:"fee"`
+	SteamFee            uint64              `json:"steam_fee"`
+	PublisherFee        uint64              `json:"publisher_fee"`
+	PublisherFeePercent float64             `json:"publisher_fee_percent,string"`
+	PublisherFeeApp     uint32              `json:"publisher_fee_app"`
+	CurrencyID          uint32              `json:"currencyid,string"`
+	ConvertedPrice      uint64              `json:"converted_price"`
+	ConvertedFee        uint64              `json:"converted_fee"`
+	Status              uint32              `json:"status"`
+	Active              int                 `json:"active"`
+	CancelReason        int                 `json:"cancel_reason"`
+	TimeFinishHold      int64               `json:"time_finish_hold"`
+}
+
+type MarketBuyOrder struct {
+	OrderID           uint64 `json:"buy_orderid,string"`
+	AppID             uint32 `json:"appid"`
+	HashName          string `json:"hash_name"`
+	WalletCurrency    uint32 `json:"wallet_currency"`
+	Price             uint64 `json:"price,string"`
+	Quantity          uint64 `json:"quantity,string"`
+	QuantityRemaining uint64 `json:"quantity_remaining,string"`
+}
+
+type MyListingsResponse struct {
+	RawResponse
+
+	Success           bool              `json:"success"`
+	Start             int               `json:"start"`
+	PageSize          int               `json:"pagesize"`
+	TotalCount        int               `json:"total_count"`
+	NumActiveListings int               `json:"num_active_listings"`
+	Listings          []*MarketListing  `json:"listings"`
+	OnHold            []*MarketListing  `json:"listings_on_hold"`
+	ToConfirm         []*MarketListing  `json:"listings_to_confirm"`
+	BuyOrders         []*MarketBuyOrder `json:"buy_orders"`
+}
+
+// GetMyListings @count is capped at 100 by Steam, note that only the active
+// listings are paged, the other lists are always returned in full.
+func (session *Session) GetMyListings(start, count int) (*MyListingsResponse, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/market/mylistings/render/?" + session.withCountry(url.Values{
+		"norender": {"1"},
+		"start":    {strconv.Itoa(start)},
+		"count":    {strconv.Itoa(count)},
+		"l":        {session.language},
+	}).Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	response := &MyListingsResponse{}
+	if err = session.decodeJSON(resp.Body, response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, ErrCannotLoadListings
+	}
+
+	return response, nil
+}
+
+// GetAllMyListings pages through every active listing, the result has all of
+// them in Listings.
+func (session *Session) GetAllMyListings() (*MyListingsResponse, error) {
+	all, err := session.GetMyListings(0, myListingsPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(all.Listings) < all.TotalCount {
+		page, err := session.GetMyListings(len(all.Listings), myListingsPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page.Listings) == 0 {
+			break
+		}
+
+		all.Listings = append(all.Listings, page.Listings...)
+	}
+
+	return all, nil
+}
+
+func findListing(listings []*MarketListing, assetID uint64) *MarketListing {
+	for _, listing := range listings {
+		if listing.Asset != nil && listing.Asset.AssetID == assetID {
+			return listing
+		}
+	}
+
+	return nil
+}
+
+// FindListingState returns where the listing of @assetID currently is.
+func (response *MyListingsResponse) FindListingState(assetID uint64) (int, *MarketListing) {
+	if listing := findListing(response.ToConfirm, assetID); listing != nil {
+		return ListingStateToConfirm, listing
+	}
+
+	if listing := findListing(response.OnHold, assetID); listing != nil {
+		return ListingStateOnHold, listing
+	}
+
+	if listing := findListing(response.Listings, assetID); listing != nil {
+		return ListingStateActive, listing
+	}
+
+	return ListingStateNotFound, nil
+}
+
+// GetListingsForAsset returns our listings of @assetID wherever they are (active,
+// on hold or to confirm), there is usually only one.
+func (session *Session) GetListingsForAsset(assetID uint64) ([]*MarketListing, error) {
+	all, err := session.GetAllMyListings()
+	if err != nil {
+		return nil, err
+	}
+
+	listings := []*MarketListing{}
+	for _, list := range [][]*MarketListing{all.Listings, all.OnHold, all.ToConfirm} {
+		for _, listing := range list {
+			if listing.Asset != nil && listing.Asset.AssetID == assetID {
+				listings = append(listings, listing)
+			}
+		}
+	}
+
+	return listings, nil
+}
+
+func (session *Session) RemoveListing(listingID uint64) error {
+	req, err := http.NewRequest(
+		http.MethodPost,
+		"https://steamcommunity.com/market/removelisting/"+strconv.FormatUint(listingID, 10),
+		strings.NewReader(url.Values{
+			"sessionid": {session.sessionID},
+		}.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Referer", "https://steamcommunity.com/market")
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := session.client.Do(req)
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot remove listing %d: %d", listingID, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// RemoveListingsForAsset removes every listing of @assetID, ErrListingNotFound
+// is returned if there is none.
+func (session *Session) RemoveListingsForAsset(assetID uint64) error {
+	listings, err := session.GetListingsForAsset(assetID)
+	if err != nil {
+		return err
+	}
+
+	if len(listings) == 0 {
+		return ErrListingNotFound
+	}
+
+	for _, listing := range listings {
+		if err = session.RemoveListing(listing.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListingEmailConfirmer is something able to confirm a listing through the
+// link Steam sends by email (e.g. by reading the mailbox over IMAP).
+type ListingEmailConfirmer interface {
+	ConfirmListingEmail(item *InventoryItem) error
+}
+
+// WaitListingConfirmation is meant for when SellItem says the listing needs email
+// confirmation: it checks the listing of @item every @interval until it's no longer
+// waiting for confirmation, @timeout passes or @ctx is done.  If @confirmer is not
+// nil, it is asked to confirm the listing before waiting.  @clock may be nil for
+// SystemClock.
+// The state returned is the last one seen, along with the listing if it was found.
+func (session *Session) WaitListingConfirmation(
+	ctx context.Context,
+	item *InventoryItem,
+	timeout, interval time.Duration,
+	confirmer ListingEmailConfirmer,
+	clock Clock,
+) (int, *MarketListing, error) {
+	if confirmer != nil {
+		if err := confirmer.ConfirmListingEmail(item); err != nil {
+			return ListingStateNotFound, nil, err
+		}
+	}
+
+	clock = clockOrSystem(clock)
+	deadline := clock.Now().Add(timeout)
+	for {
+		listings, err := session.GetAllMyListings()
+		if err != nil {
+			return ListingStateNotFound, nil, err
+		}
+
+		state, listing := listings.FindListingState(item.AssetID)
+		if state != ListingStateToConfirm {
+			return state, listing, nil
+		}
+
+		if clock.Now().Add(interval).After(deadline) {
+			return state, listing, ErrListingConfirmationTimeout
+		}
+
+		if !wait(clock, ctx.Done(), interval) {
+			return state, listing, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package steam
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type NotificationCounts struct {
+	TradeOffers        uint32 `json:"1"`
+	GameTurns          uint32 `json:"2"`
+	ModeratorMessages  uint32 `json:"3"`
+	Comments           uint32 `json:"4"`
+	Items              uint32 `json:"5"`
+	Invites            uint32 `json:"6"`
+	Gifts              uint32 `json:"8"`
+	OfflineMessages    uint32 `json:"9"`
+	HelpRequestReplies uint32 `json:"10"`
+	AccountAlerts      uint32 `json:"11"`
+}
+
+// GetNotificationCounts returns what is shown in the green envelope on the community,
+// this is cheap and a good thing to check before doing anything more expensive.
+func (session *Session) GetNotificationCounts() (*NotificationCounts, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/actions/GetNotificationCounts")
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Inner *NotificationCounts `json:"notifications"`
+	}
+
+	var response Response
+	if err = session.decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Inner == nil {
+		return &NotificationCounts{}, nil
+	}
+
+	return response.Inner, nil
+}
@@ -0,0 +1,104 @@
+package steam
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	NotificationTradeOffer = "trade_offer"
+	NotificationPrice      = "price"
+	NotificationAnomaly    = "anomaly"
+)
+
+// Notification is what the background components (TradeOfferManager, PriceWatcher,
+// storage.AnomalyDetector) report to their Notifier.  Data is the *TradeOfferAction,
+// *PriceAlert or *storage.AnomalyAlert.
+type Notification struct {
+	Source string      `json:"source"`
+	Title  string      `json:"title"`
+	Text   string      `json:"text"`
+	Time   time.Time   `json:"time"`
+	Data   interface{} `json:"data,omitempty"`
+}
+
+type Notifier interface {
+	Notify(notification *Notification) error
+}
+
+// PayloadFormatter turns a notification into the body of a webhook request.
+type PayloadFormatter func(notification *Notification) ([]byte, error)
+
+// JSONPayload is the notification itself as JSON.
+func JSONPayload(notification *Notification) ([]byte, error) {
+	return json.Marshal(notification)
+}
+
+// DiscordPayload formats for a Discord webhook.
+func DiscordPayload(notification *Notification) ([]byte, error) {
+	type Embed struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Timestamp   string `json:"timestamp"`
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"embeds": []Embed{{
+			Title:       notification.Title,
+			Description: notification.Text,
+			Timestamp:   notification.Time.Format(time.RFC3339),
+		}},
+	})
+}
+
+// SlackPayload formats for a Slack incoming webhook.
+func SlackPayload(notification *Notification) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"text": "*" + notification.Title + "*\n" + notification.Text,
+	})
+}
+
+// WebhookNotifier posts notifications to URL, formatted by Format.
+type WebhookNotifier struct {
+	Client *http.Client
+	URL    string
+	Format PayloadFormatter
+}
+
+// NewWebhookNotifier @format may be nil for JSONPayload.
+func NewWebhookNotifier(url string, format PayloadFormatter) *WebhookNotifier {
+	if format == nil {
+		format = JSONPayload
+	}
+
+	return &WebhookNotifier{
+		Client: &http.Client{Timeout: 10 * time.Second},
+		URL:    url,
+		Format: format,
+	}
+}
+
+func (notifier *WebhookNotifier) Notify(notification *Notification) error {
+	body, err := notifier.Format(notification)
+	if err != nil {
+		return err
+	}
+
+	resp, err := notifier.Client.Post(notifier.URL, "application/json", bytes.NewReader(body))
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	return nil
+}
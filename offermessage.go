@@ -0,0 +1,149 @@
+package steam
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxOfferMessageLength is the most characters Steam keeps of an offer message.
+const MaxOfferMessageLength = 128
+
+var (
+	ErrOfferMessageTooLong = errors.New("trade offer message is too long")
+	ErrNoOfferTemplate     = errors.New("no trade offer message template for the language")
+)
+
+// OfferMessageData is what offer message templates are executed with.
+type OfferMessageData struct {
+	PartnerName string
+	// Items are summarized (e.g. "2x Mann Co. Supply Crate Key, Tour of Duty Ticket")
+	// by the template functions, see OfferMessageTemplate.
+	SendItems []*EconItem
+	RecvItems []*EconItem
+	Expires   time.Time
+}
+
+// ValidateOfferMessage returns ErrOfferMessageTooLong past MaxOfferMessageLength.
+func ValidateOfferMessage(message string) error {
+	if utf8.RuneCountInString(message) > MaxOfferMessageLength {
+		return ErrOfferMessageTooLong
+	}
+
+	return nil
+}
+
+// SummarizeItems counts @items by name, in order of first appearance, items without
+// a description are counted as "unknown item".
+func SummarizeItems(items []*EconItem) string {
+	names := []string{}
+	counts := map[string]uint64{}
+	for _, item := range items {
+		name := "unknown item"
+		if item.Desc != nil {
+			name = item.Desc.MarketName
+		}
+
+		if _, ok := counts[name]; !ok {
+			names = append(names, name)
+		}
+
+		amount := item.Amount
+		if amount == 0 {
+			amount = 1
+		}
+		counts[name] += amount
+	}
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		if counts[name] > 1 {
+			parts[i] = fmt.Sprintf("%dx %s", counts[name], name)
+		} else {
+			parts[i] = name
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// OfferMessageTemplate is a text/template for offer messages, on top of the fields
+// of OfferMessageData it has the functions:
+//
+//	summary  items -> SummarizeItems
+//	date     layout time -> the time formatted with layout
+//	truncate n text -> text cut to n characters, with "..." if it was longer
+type OfferMessageTemplate struct {
+	tmpl *template.Template
+}
+
+func truncateText(n int, text string) string {
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+
+	if n <= 3 {
+		return string([]rune(text)[:n])
+	}
+
+	return string([]rune(text)[:n-3]) + "..."
+}
+
+func NewOfferMessageTemplate(text string) (*OfferMessageTemplate, error) {
+	tmpl, err := template.New("offer").Funcs(template.FuncMap{
+		"summary":  SummarizeItems,
+		"date":     func(layout string, t time.Time) string { return t.Format(layout) },
+		"truncate": truncateText,
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OfferMessageTemplate{tmpl}, nil
+}
+
+// Render executes the template and checks the result with ValidateOfferMessage.
+func (t *OfferMessageTemplate) Render(data *OfferMessageData) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := t.tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+
+	message := strings.TrimSpace(buf.String())
+	return message, ValidateOfferMessage(message)
+}
+
+// OfferMessageTemplates are templates by language (as in SetLanguage), "english"
+// is used for the languages without one.
+type OfferMessageTemplates map[string]*OfferMessageTemplate
+
+func (templates OfferMessageTemplates) Render(language string, data *OfferMessageData) (string, error) {
+	t, ok := templates[language]
+	if !ok {
+		if t, ok = templates["english"]; !ok {
+			return "", ErrNoOfferTemplate
+		}
+	}
+
+	return t.Render(data)
+}
+
+// SetMessage renders @t for the offer and sets it as its message.
+func (offer *TradeOffer) SetMessage(t *OfferMessageTemplate, partnerName string, expires time.Time) error {
+	message, err := t.Render(&OfferMessageData{
+		PartnerName: partnerName,
+		SendItems:   offer.SendItems,
+		RecvItems:   offer.RecvItems,
+		Expires:     expires,
+	})
+	if err != nil {
+		return err
+	}
+
+	offer.Message = message
+	return nil
+}
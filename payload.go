@@ -0,0 +1,62 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// How much of the body PayloadError keeps.
+const payloadSampleSize = 512
+
+var ErrUnexpectedPayload = errors.New("unexpected payload")
+
+// PayloadError is returned when a response doesn't have the expected shape,
+// Sample is the beginning of the body, for bug reports.  It wraps
+// ErrUnexpectedPayload.
+type PayloadError struct {
+	Cause  interface{} // What went wrong, e.g. the recovered panic
+	Sample []byte
+}
+
+func (err *PayloadError) Error() string {
+	return fmt.Sprintf("%v: %v: %q", ErrUnexpectedPayload, err.Cause, err.Sample)
+}
+
+func (err *PayloadError) Unwrap() error {
+	return ErrUnexpectedPayload
+}
+
+// payloadSample keeps the first bytes read through it.
+type payloadSample struct {
+	r   io.Reader
+	buf []byte
+}
+
+func newPayloadSample(r io.Reader) *payloadSample {
+	return &payloadSample{r: r}
+}
+
+func (sample *payloadSample) Read(p []byte) (int, error) {
+	n, err := sample.r.Read(p)
+	if left := payloadSampleSize - len(sample.buf); left > 0 {
+		if left > n {
+			left = n
+		}
+		sample.buf = append(sample.buf, p[:left]...)
+	}
+
+	return n, err
+}
+
+func (sample *payloadSample) error(cause interface{}) error {
+	return &PayloadError{Cause: cause, Sample: sample.buf}
+}
+
+// recoverPayload is deferred by the methods parsing payloads in ways that
+// can panic (type assertions, indexing), it turns the panic into a PayloadError.
+func recoverPayload(err *error, sample *payloadSample) {
+	if r := recover(); r != nil {
+		*err = sample.error(r)
+	}
+}
@@ -0,0 +1,133 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrPollerStarted = errors.New("player count poller already started")
+
+type PlayerCountSample struct {
+	AppID uint32
+	Count uint32
+	Time  time.Time
+}
+
+// PlayerCountStore keeps the samples of PlayerCountPoller.
+type PlayerCountStore interface {
+	AddPlayerCount(sample *PlayerCountSample) error
+}
+
+// PlayerCountPoller samples the number of current players of AppIDs every
+// Interval, e.g. to correlate them with item prices.
+type PlayerCountPoller struct {
+	session *Session
+
+	AppIDs   []uint32
+	Interval time.Duration
+	// Jitter moves each wait by up to this fraction of Interval, see Jitter.
+	Jitter float64
+	Clock  Clock
+	// Store gets every sample, may be nil.
+	Store PlayerCountStore
+	// OnSample is called for every sample, may be nil.
+	OnSample func(*PlayerCountSample)
+	// OnError is called with the errors of Start, may be nil.
+	OnError func(error)
+
+	lifecycle
+}
+
+// NewPlayerCountPoller @store and @clock may be nil, the latter for SystemClock.
+func (session *Session) NewPlayerCountPoller(appIDs []uint32, interval time.Duration, store PlayerCountStore, clock Clock) *PlayerCountPoller {
+	return &PlayerCountPoller{
+		session:  session,
+		AppIDs:   appIDs,
+		Interval: interval,
+		Store:    store,
+		Clock:    clockOrSystem(clock),
+	}
+}
+
+// PlayerCountError is the error of a single app during Poll, either fetching its
+// count or storing its sample.
+type PlayerCountError struct {
+	AppID uint32
+	Err   error
+}
+
+func (err *PlayerCountError) Error() string {
+	return fmt.Sprintf("app %d: %v", err.AppID, err.Err)
+}
+
+func (err *PlayerCountError) Unwrap() error {
+	return err.Err
+}
+
+// PlayerCountErrors are the apps that failed during Poll.
+type PlayerCountErrors []*PlayerCountError
+
+func (errs PlayerCountErrors) Error() string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+
+	return fmt.Sprintf("%d apps failed, first error: %v", len(errs), errs[0])
+}
+
+// Unwrap returns the error of the first failed app.
+func (errs PlayerCountErrors) Unwrap() error {
+	return errs[0].Err
+}
+
+// Poll samples every app once, an app failing doesn't stop the others, the
+// failures are returned together as PlayerCountErrors.
+func (poller *PlayerCountPoller) Poll() error {
+	var errs PlayerCountErrors
+	for _, appID := range poller.AppIDs {
+		count, err := poller.session.GetNumberOfCurrentPlayers(appID)
+		if err != nil {
+			errs = append(errs, &PlayerCountError{AppID: appID, Err: err})
+			continue
+		}
+
+		sample := &PlayerCountSample{
+			AppID: appID,
+			Count: count,
+			Time:  clockOrSystem(poller.Clock).Now(),
+		}
+
+		if poller.OnSample != nil {
+			poller.OnSample(sample)
+		}
+
+		if poller.Store != nil {
+			if err = poller.Store.AddPlayerCount(sample); err != nil {
+				errs = append(errs, &PlayerCountError{AppID: appID, Err: err})
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// Start calls Poll every Interval until Stop or @ctx is done.
+func (poller *PlayerCountPoller) Start(ctx context.Context) error {
+	return poller.start(ctx, ErrPollerStarted, nil, func(stop chan struct{}) {
+		for {
+			if err := poller.Poll(); err != nil && poller.OnError != nil {
+				poller.OnError(err)
+			}
+
+			if !wait(poller.Clock, stop, Jitter(poller.Interval, poller.Jitter)) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package steam
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type playerCountTransport map[string]string
+
+func (transport playerCountTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := transport[req.URL.Query().Get("appid")]
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestPlayerCountPollerPollCollectsErrors(t *testing.T) {
+	session := NewSession(&http.Client{Transport: playerCountTransport{
+		"440": `{"response":{"player_count":100,"result":1}}`,
+		"730": `{"response":{"result":42}}`,
+		"570": `{"response":{"player_count":300,"result":1}}`,
+	}}, "")
+
+	var sampled []uint32
+	poller := session.NewPlayerCountPoller([]uint32{1, 440, 730, 570}, 0, nil, nil)
+	poller.OnSample = func(sample *PlayerCountSample) {
+		sampled = append(sampled, sample.AppID)
+	}
+
+	err := poller.Poll()
+	errs, ok := err.(PlayerCountErrors)
+	if !ok || len(errs) != 2 || errs[0].AppID != 1 || errs[1].AppID != 730 {
+		t.Fatalf("err = %v, want apps 1 and 730 failed", err)
+	}
+
+	if len(sampled) != 2 || sampled[0] != 440 || sampled[1] != 570 {
+		t.Errorf("sampled = %v, want 440 and 570", sampled)
+	}
+}
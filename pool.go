@@ -0,0 +1,283 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrPoolStarted        = errors.New("session pool already started")
+	ErrNoHealthySession   = errors.New("no healthy session in the pool")
+	ErrUnknownPoolAccount = errors.New("unknown pool account")
+)
+
+type PoolAccount struct {
+	Name         string
+	Password     string
+	SharedSecret string
+	TimeOffset   time.Duration
+	// RateInterval spaces the requests of this account, zero disables it.
+	RateInterval time.Duration
+}
+
+// SessionStateStore persists the state of the pool sessions so that they don't
+// need to login again after a restart.  Load returns nil if there is none.
+type SessionStateStore interface {
+	LoadSessionState(name string) (*SessionState, error)
+	SaveSessionState(name string, state *SessionState) error
+}
+
+type poolMember struct {
+	account *PoolAccount
+	session *Session
+	healthy bool
+	// Serializes logins of this account.
+	mutex sync.Mutex
+}
+
+// SessionPool manages sessions of several accounts, requests are spread over
+// the healthy ones with Get, or kept on the same account with GetFor for the
+// operations that depend on it (e.g. the listings of an account).
+type SessionPool struct {
+	client *http.Client
+	apiKey string
+	store  SessionStateStore
+
+	// HealthInterval is how often Start checks the sessions.
+	HealthInterval time.Duration
+	// HealthJitter moves each wait by up to this fraction of HealthInterval.
+	HealthJitter float64
+	Clock        Clock
+	// OnError is called with the errors of the background health checks, may be nil.
+	OnError func(name string, err error)
+
+	members  []*poolMember
+	byName   map[string]*poolMember
+	affinity map[string]*poolMember
+	next     int
+
+	mutex sync.Mutex
+	lifecycle
+}
+
+// NewSessionPool every session is made with @client, like NewSession, @store may be nil.
+func NewSessionPool(client *http.Client, apiKey string, store SessionStateStore) *SessionPool {
+	return &SessionPool{
+		client:         client,
+		apiKey:         apiKey,
+		store:          store,
+		HealthInterval: 5 * time.Minute,
+		Clock:          SystemClock,
+		byName:         make(map[string]*poolMember),
+		affinity:       make(map[string]*poolMember),
+	}
+}
+
+// Add adds @account to the pool, it isn't logged in until CheckHealth (or Start).
+func (pool *SessionPool) Add(account *PoolAccount) *Session {
+	session := NewSession(pool.client, pool.apiKey)
+	if account.RateInterval != 0 {
+		session.SetRateLimiter(NewRateLimiter(account.RateInterval))
+	}
+
+	member := &poolMember{
+		account: account,
+		session: session,
+	}
+
+	pool.mutex.Lock()
+	pool.members = append(pool.members, member)
+	pool.byName[account.Name] = member
+	pool.mutex.Unlock()
+	return session
+}
+
+// Session returns the session of account @name, whether it's healthy or not.
+func (pool *SessionPool) Session(name string) *Session {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if member, ok := pool.byName[name]; ok {
+		return member.session
+	}
+
+	return nil
+}
+
+// repair makes @member logged in, restoring the stored state if it's still valid,
+// logging in otherwise.
+func (pool *SessionPool) repair(ctx context.Context, member *poolMember) error {
+	member.mutex.Lock()
+	defer member.mutex.Unlock()
+
+	if pool.store != nil && !pool.isHealthy(member) {
+		state, err := pool.store.LoadSessionState(member.account.Name)
+		if err != nil {
+			return err
+		}
+
+		if state != nil {
+			if err = member.session.RestoreState(state); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, ok, err := member.session.IsLoggedIn(ctx); err != nil {
+		// Giving up on the check doesn't tell anything about the session.
+		if ctx.Err() == nil {
+			pool.setHealthy(member, false)
+		}
+		return err
+	} else if ok {
+		pool.setHealthy(member, true)
+		return nil
+	}
+
+	account := member.account
+	if err := member.session.Login(account.Name, account.Password, account.SharedSecret, account.TimeOffset); err != nil {
+		pool.setHealthy(member, false)
+		return err
+	}
+
+	pool.setHealthy(member, true)
+	if pool.store != nil {
+		return pool.store.SaveSessionState(account.Name, member.session.ExportState())
+	}
+
+	return nil
+}
+
+func (pool *SessionPool) isHealthy(member *poolMember) bool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	return member.healthy
+}
+
+func (pool *SessionPool) setHealthy(member *poolMember, healthy bool) {
+	pool.mutex.Lock()
+	member.healthy = healthy
+	pool.mutex.Unlock()
+}
+
+func (pool *SessionPool) snapshot() []*poolMember {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	members := make([]*poolMember, len(pool.members))
+	copy(members, pool.members)
+	return members
+}
+
+// CheckHealth checks every session and logs in again those that aren't logged in
+// anymore, it returns the errors by account name.  It stops once @ctx is done.
+func (pool *SessionPool) CheckHealth(ctx context.Context) map[string]error {
+	errs := make(map[string]error)
+	for _, member := range pool.snapshot() {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if err := pool.repair(ctx, member); err != nil {
+			errs[member.account.Name] = err
+		}
+	}
+
+	return errs
+}
+
+// Get returns the next healthy session, in turn.
+func (pool *SessionPool) Get() (*Session, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for i := 0; i < len(pool.members); i++ {
+		member := pool.members[(pool.next+i)%len(pool.members)]
+		if member.healthy {
+			pool.next = (pool.next + i + 1) % len(pool.members)
+			return member.session, nil
+		}
+	}
+
+	return nil, ErrNoHealthySession
+}
+
+// GetFor always returns the same session for @key, the first call picks it with Get.
+// Bind sets it explicitly, e.g. to the account owning a listing.  If the session
+// isn't healthy, it's repaired rather than swapped for another.
+func (pool *SessionPool) GetFor(ctx context.Context, key string) (*Session, error) {
+	pool.mutex.Lock()
+	member, ok := pool.affinity[key]
+	pool.mutex.Unlock()
+
+	if !ok {
+		session, err := pool.Get()
+		if err != nil {
+			return nil, err
+		}
+
+		pool.mutex.Lock()
+		for _, m := range pool.members {
+			if m.session == session {
+				member = m
+			}
+		}
+		// Another call may have picked one meanwhile.
+		if m, ok := pool.affinity[key]; ok {
+			member = m
+		} else {
+			pool.affinity[key] = member
+		}
+		pool.mutex.Unlock()
+	}
+
+	if !pool.isHealthy(member) {
+		if err := pool.repair(ctx, member); err != nil {
+			return nil, err
+		}
+	}
+
+	return member.session, nil
+}
+
+// Bind makes GetFor(@key) return the session of account @name.
+func (pool *SessionPool) Bind(key, name string) error {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	member, ok := pool.byName[name]
+	if !ok {
+		return ErrUnknownPoolAccount
+	}
+
+	pool.affinity[key] = member
+	return nil
+}
+
+// Unbind forgets the session of @key.
+func (pool *SessionPool) Unbind(key string) {
+	pool.mutex.Lock()
+	delete(pool.affinity, key)
+	pool.mutex.Unlock()
+}
+
+// Start runs CheckHealth every HealthInterval, starting now, until Stop or @ctx is done,
+// the check in flight is given up once @ctx is done.
+func (pool *SessionPool) Start(ctx context.Context) error {
+	return pool.start(ctx, ErrPoolStarted, nil, func(stop chan struct{}) {
+		for {
+			for name, err := range pool.CheckHealth(ctx) {
+				if pool.OnError != nil && ctx.Err() == nil {
+					pool.OnError(name, err)
+				}
+			}
+
+			if !wait(pool.Clock, stop, Jitter(pool.HealthInterval, pool.HealthJitter)) {
+				return
+			}
+		}
+	})
+}
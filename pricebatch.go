@@ -0,0 +1,91 @@
+package steam
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+type PriceHistoryItem struct {
+	AppID          uint64
+	MarketHashName string
+}
+
+// PriceHistoryResult is the price history of Item, or the error fetching it.
+type PriceHistoryResult struct {
+	Item   PriceHistoryItem
+	Prices []*MarketItemPrice
+	Err    error
+}
+
+// priceHistoryShared is GetMarketItemPriceHistory shared between the callers asking
+// for the same item at the same time.  The prices are shared too, they must not be
+// modified.
+func (session *Session) priceHistoryShared(item PriceHistoryItem) ([]*MarketItemPrice, error) {
+	key := strconv.FormatUint(item.AppID, 10) + "/" + item.MarketHashName
+	prices, err, _ := session.priceHistoryFlight.Do(key, func() (interface{}, error) {
+		return session.GetMarketItemPriceHistory(item.AppID, item.MarketHashName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prices.([]*MarketItemPrice), nil
+}
+
+// GetPriceHistoryForMultipleItems fetches the price history of every item of @items,
+// with at most @parallel requests at once, each waiting on @limiter first (with
+// PriorityLow) when it's not nil.  The same limiter can be given to several batches
+// so that they share its budget, and batches asking for the same item at the same
+// time share the request.
+// Results are sent as they complete, the channel is closed once every item is done
+// or @ctx is, in which case the items not started yet are left out.
+func (session *Session) GetPriceHistoryForMultipleItems(
+	ctx context.Context,
+	items []PriceHistoryItem,
+	limiter *RateLimiter,
+	parallel int,
+) <-chan *PriceHistoryResult {
+	if parallel <= 0 {
+		parallel = 1
+	}
+
+	results := make(chan *PriceHistoryResult, parallel)
+	queue := make(chan PriceHistoryItem)
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for item := range queue {
+				if limiter != nil && limiter.WaitContext(ctx, PriorityLow) != nil {
+					continue
+				}
+
+				prices, err := session.priceHistoryShared(item)
+				select {
+				case results <- &PriceHistoryResult{Item: item, Prices: prices, Err: err}:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(results)
+		defer wg.Wait()
+		defer close(queue)
+
+		for _, item := range items {
+			select {
+			case queue <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results
+}
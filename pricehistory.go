@@ -0,0 +1,245 @@
+package steam
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Steam formats price history dates as "Jul 02 2014 01: +0".
+const priceHistoryDateLayout = "Jan 02 2006 15"
+
+// PriceBucket aggregates the price points within [Start, Start + period).
+type PriceBucket struct {
+	Start  time.Time
+	Open   float64
+	Close  float64
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	VWAP   float64
+	Volume uint64
+	Points int
+}
+
+// Time parses Date, always in UTC.
+func (price *MarketItemPrice) Time() (time.Time, error) {
+	date := price.Date
+	if i := strings.Index(date, ":"); i != -1 {
+		date = date[:i]
+	}
+
+	return time.ParseInLocation(priceHistoryDateLayout, date, time.UTC)
+}
+
+// Volume is Count as a number, 0 if it cannot be parsed.
+func (price *MarketItemPrice) Volume() uint64 {
+	volume, _ := strconv.ParseUint(strings.Replace(price.Count, ",", "", -1), 10, 64)
+	return volume
+}
+
+func priceValues(prices []*MarketItemPrice) []float64 {
+	values := make([]float64, len(prices))
+	for i, price := range prices {
+		values[i] = price.Price
+	}
+
+	return values
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+func PriceMedian(prices []*MarketItemPrice) float64 {
+	return median(priceValues(prices))
+}
+
+func PriceMean(prices []*MarketItemPrice) float64 {
+	return mean(priceValues(prices))
+}
+
+func PriceVolume(prices []*MarketItemPrice) uint64 {
+	volume := uint64(0)
+	for _, price := range prices {
+		volume += price.Volume()
+	}
+
+	return volume
+}
+
+// PriceVolatility is the standard deviation of the log returns between
+// consecutive points, so it depends on the spacing of @prices.
+func PriceVolatility(prices []*MarketItemPrice) float64 {
+	returns := []float64{}
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1].Price > 0 && prices[i].Price > 0 {
+			returns = append(returns, math.Log(prices[i].Price/prices[i-1].Price))
+		}
+	}
+
+	if len(returns) < 2 {
+		return 0
+	}
+
+	m := mean(returns)
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - m) * (r - m)
+	}
+
+	return math.Sqrt(variance / float64(len(returns)-1))
+}
+
+func resamplePrices(prices []*MarketItemPrice, bucketStart func(time.Time) time.Time) ([]*PriceBucket, error) {
+	buckets := []*PriceBucket{}
+	var points []*MarketItemPrice
+
+	flush := func() {
+		if len(points) == 0 {
+			return
+		}
+
+		bucket := buckets[len(buckets)-1]
+		bucket.Open = points[0].Price
+		bucket.Close = points[len(points)-1].Price
+		bucket.Min, bucket.Max = points[0].Price, points[0].Price
+		for _, point := range points {
+			bucket.Min = math.Min(bucket.Min, point.Price)
+			bucket.Max = math.Max(bucket.Max, point.Price)
+		}
+		bucket.Mean = PriceMean(points)
+		bucket.Median = PriceMedian(points)
+		bucket.VWAP = PriceVWAP(points)
+		bucket.Volume = PriceVolume(points)
+		bucket.Points = len(points)
+		points = nil
+	}
+
+	for _, price := range prices {
+		t, err := price.Time()
+		if err != nil {
+			return nil, err
+		}
+
+		start := bucketStart(t)
+		if len(buckets) == 0 || !buckets[len(buckets)-1].Start.Equal(start) {
+			flush()
+			buckets = append(buckets, &PriceBucket{Start: start})
+		}
+
+		points = append(points, price)
+	}
+
+	flush()
+	return buckets, nil
+}
+
+// ResamplePriceHistory groups @prices (sorted by date, as Steam returns them)
+// into buckets of @period, buckets without any points are omitted.
+func ResamplePriceHistory(prices []*MarketItemPrice, period time.Duration) ([]*PriceBucket, error) {
+	return resamplePrices(prices, func(t time.Time) time.Time {
+		return t.Truncate(period)
+	})
+}
+
+func DailyPrices(prices []*MarketItemPrice) ([]*PriceBucket, error) {
+	return ResamplePriceHistory(prices, 24*time.Hour)
+}
+
+// WeeklyPrices buckets start on Monday.
+func WeeklyPrices(prices []*MarketItemPrice) ([]*PriceBucket, error) {
+	return resamplePrices(prices, func(t time.Time) time.Time {
+		day := t.Truncate(24 * time.Hour)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	})
+}
+
+// PricesSince returns the points of @prices at or after @since, the point
+// of the current hour keeps changing until the hour is over, so the last point
+// of the previous fetch is expected to be returned again.
+func PricesSince(prices []*MarketItemPrice, since time.Time) ([]*MarketItemPrice, error) {
+	newer := []*MarketItemPrice{}
+	for _, price := range prices {
+		t, err := price.Time()
+		if err != nil {
+			return nil, err
+		}
+
+		if !t.Before(since) {
+			newer = append(newer, price)
+		}
+	}
+
+	return newer, nil
+}
+
+// GetMarketItemPriceHistorySince is GetMarketItemPriceHistory filtered with PricesSince,
+// Steam has no way of asking for less so the full history is still downloaded.
+func (session *Session) GetMarketItemPriceHistorySince(appID uint64, marketHashName string, since time.Time) ([]*MarketItemPrice, error) {
+	prices, err := session.GetMarketItemPriceHistory(appID, marketHashName)
+	if err != nil {
+		return nil, err
+	}
+
+	return PricesSince(prices, since)
+}
+
+// PriceHistoryStore keeps price histories for UpdatePriceHistory.
+type PriceHistoryStore interface {
+	// LastPriceTime returns the time of the newest point stored for the item,
+	// or a zero time if there is none.
+	LastPriceTime(appID uint64, marketHashName string) (time.Time, error)
+	// AddPrices stores @prices, points already stored for the same time must be
+	// replaced.
+	AddPrices(appID uint64, marketHashName string, prices []*MarketItemPrice) error
+}
+
+// UpdatePriceHistory fetches the price history of an item and adds to @store the
+// points it doesn't have yet, it returns how many points were passed to the store.
+func (session *Session) UpdatePriceHistory(store PriceHistoryStore, appID uint64, marketHashName string) (int, error) {
+	since, err := store.LastPriceTime(appID, marketHashName)
+	if err != nil {
+		return 0, err
+	}
+
+	prices, err := session.GetMarketItemPriceHistorySince(appID, marketHashName, since)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(prices) == 0 {
+		return 0, nil
+	}
+
+	return len(prices), store.AddPrices(appID, marketHashName, prices)
+}
@@ -0,0 +1,171 @@
+package steam
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Maximum count market search accepts per page.
+const marketSearchPageSize = 100
+
+// PriceIndexEntry prices are in cents of the index currency, 0 when the overview
+// had none (or couldn't be fetched, see PriceIndex.Failed).
+type PriceIndexEntry struct {
+	HashName string `json:"hash_name"`
+	Listings uint64 `json:"listings"`
+	Lowest   uint64 `json:"lowest,omitempty"`
+	Median   uint64 `json:"median,omitempty"`
+	Volume   uint64 `json:"volume,omitempty"`
+}
+
+// PriceIndex is a snapshot of the prices of the most popular items of an app,
+// in popularity order.
+type PriceIndex struct {
+	AppID      uint64             `json:"appid"`
+	Country    string             `json:"country,omitempty"`
+	CurrencyID string             `json:"currency"`
+	Time       time.Time          `json:"time"`
+	Failed     int                `json:"failed,omitempty"`
+	Items      []*PriceIndexEntry `json:"items"`
+}
+
+func (index *PriceIndex) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(index)
+}
+
+// searchPopular returns up to @count of the most popular items of @appID, waiting
+// on @limiter (when not nil) before every page.
+func (session *Session) searchPopular(ctx context.Context, appID uint64, count int, limiter *RateLimiter) ([]*MarketSearchItem, error) {
+	items := []*MarketSearchItem{}
+	for len(items) < count {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if limiter != nil {
+			if err := limiter.WaitContext(ctx, PriorityLow); err != nil {
+				return nil, err
+			}
+		}
+
+		pageSize := count - len(items)
+		if pageSize > marketSearchPageSize {
+			pageSize = marketSearchPageSize
+		}
+
+		response, page, err := session.searchMarket(session.withCountry(url.Values{
+			"appid":       {strconv.FormatUint(appID, 10)},
+			"start":       {strconv.Itoa(len(items))},
+			"count":       {strconv.Itoa(pageSize)},
+			"sort_column": {"popular"},
+			"sort_dir":    {"desc"},
+			"l":           {session.language},
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+		if len(page) == 0 || len(items) >= response.TotalCount {
+			break
+		}
+	}
+
+	if len(items) > count {
+		items = items[:count]
+	}
+
+	return items, nil
+}
+
+// BuildPriceIndex builds the price index of the @topN most popular items of @appID
+// in @region, the overviews are fetched @parallel at a time, each waiting on @limiter
+// first (with PriorityLow) when it's not nil, like the search pages.
+// Only the search failing is an error, items whose overview fails are counted in
+// Failed and keep their listing count.
+func (session *Session) BuildPriceIndex(
+	ctx context.Context,
+	appID uint64,
+	region MarketRegion,
+	topN int,
+	limiter *RateLimiter,
+	parallel int,
+) (*PriceIndex, error) {
+	if parallel <= 0 {
+		parallel = 1
+	}
+
+	items, err := session.searchPopular(ctx, appID, topN, limiter)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &PriceIndex{
+		AppID:      appID,
+		Country:    region.Country,
+		CurrencyID: region.CurrencyID,
+		Time:       time.Now(),
+		Items:      make([]*PriceIndexEntry, len(items)),
+	}
+
+	queue := make(chan int)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				entry := index.Items[i]
+				if limiter != nil && limiter.WaitContext(ctx, PriorityLow) != nil {
+					continue
+				}
+
+				overview, err := session.GetMarketItemPriceOverview(appID, region.Country, region.CurrencyID, entry.HashName)
+				if err != nil {
+					mutex.Lock()
+					index.Failed++
+					mutex.Unlock()
+					continue
+				}
+
+				entry.Lowest = overview.ParsedLowest
+				entry.Median = overview.ParsedMedian
+				entry.Volume = overview.ParsedVolume
+			}
+		}()
+	}
+
+	for i, item := range items {
+		index.Items[i] = &PriceIndexEntry{
+			HashName: item.HashName,
+			Listings: uint64(item.SellListings),
+		}
+	}
+
+	for i := range items {
+		select {
+		case queue <- i:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	close(queue)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
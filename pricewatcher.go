@@ -0,0 +1,159 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrWatcherStarted = errors.New("price watcher already started")
+
+// PriceWatch alerts when the lowest price of an item goes below Below or above
+// Above (in cents), a zero threshold is disabled.
+type PriceWatch struct {
+	AppID          uint64
+	MarketHashName string
+	Below          uint64
+	Above          uint64
+
+	// Whether the price is currently past a threshold, so that it alerts
+	// only once each time it's crossed.
+	crossed bool
+}
+
+type PriceAlert struct {
+	Watch    *PriceWatch
+	Price    uint64
+	Overview *MarketItemPriceOverview
+}
+
+// PriceWatcher checks the price overview of the watched items every Interval.
+type PriceWatcher struct {
+	session *Session
+
+	// Country may be empty for the session country, see SetCountry.
+	Country    string
+	CurrencyID string
+	Watches    []*PriceWatch
+	Interval   time.Duration
+	// Jitter moves each wait by up to this fraction of Interval, see Jitter.
+	Jitter float64
+	Clock  Clock
+	// OnAlert is called for every alert, may be nil.
+	OnAlert func(*PriceAlert)
+	// Notifier is notified of every alert, may be nil.
+	Notifier Notifier
+	// OnError is called with the errors of Start, may be nil.
+	OnError func(error)
+
+	lifecycle
+}
+
+// NewPriceWatcher @clock may be nil for SystemClock.
+func (session *Session) NewPriceWatcher(interval time.Duration, clock Clock) *PriceWatcher {
+	return &PriceWatcher{
+		session:    session,
+		CurrencyID: CurrencyUSD,
+		Interval:   interval,
+		Clock:      clockOrSystem(clock),
+	}
+}
+
+func (watcher *PriceWatcher) alert(alert *PriceAlert) error {
+	if watcher.OnAlert != nil {
+		watcher.OnAlert(alert)
+	}
+
+	if watcher.Notifier == nil {
+		return nil
+	}
+
+	return watcher.Notifier.Notify(&Notification{
+		Source: NotificationPrice,
+		Title:  alert.Watch.MarketHashName,
+		Text:   fmt.Sprintf("Lowest price is now %s", alert.Overview.LowestPrice),
+		Time:   clockOrSystem(watcher.Clock).Now(),
+		Data:   alert,
+	})
+}
+
+// PriceWatchError is the error of a single watch during Check, either fetching its
+// price or notifying its alert.
+type PriceWatchError struct {
+	Watch *PriceWatch
+	Err   error
+}
+
+func (err *PriceWatchError) Error() string {
+	return fmt.Sprintf("%s: %v", err.Watch.MarketHashName, err.Err)
+}
+
+func (err *PriceWatchError) Unwrap() error {
+	return err.Err
+}
+
+// PriceWatchErrors are the watches that failed during Check.
+type PriceWatchErrors []*PriceWatchError
+
+func (errs PriceWatchErrors) Error() string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+
+	return fmt.Sprintf("%d price watches failed, first error: %v", len(errs), errs[0])
+}
+
+// Unwrap returns the error of the first failed watch.
+func (errs PriceWatchErrors) Unwrap() error {
+	return errs[0].Err
+}
+
+// Check goes over the watches once, a watch failing doesn't stop the others, the
+// failures are returned together as PriceWatchErrors.  A watch whose alert couldn't
+// be notified alerts again on the next Check.
+func (watcher *PriceWatcher) Check() error {
+	var errs PriceWatchErrors
+	for _, watch := range watcher.Watches {
+		overview, err := watcher.session.GetMarketItemPriceOverview(watch.AppID, watcher.Country, watcher.CurrencyID, watch.MarketHashName)
+		if err != nil {
+			errs = append(errs, &PriceWatchError{Watch: watch, Err: err})
+			continue
+		}
+
+		price := overview.ParsedLowest
+		if !overview.Success || price == 0 {
+			continue
+		}
+
+		crossed := (watch.Below != 0 && price < watch.Below) || (watch.Above != 0 && price > watch.Above)
+		if crossed && !watch.crossed {
+			if err = watcher.alert(&PriceAlert{Watch: watch, Price: price, Overview: overview}); err != nil {
+				errs = append(errs, &PriceWatchError{Watch: watch, Err: err})
+				continue
+			}
+		}
+		watch.crossed = crossed
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// Start calls Check every Interval until Stop or @ctx is done.
+func (watcher *PriceWatcher) Start(ctx context.Context) error {
+	return watcher.start(ctx, ErrWatcherStarted, nil, func(stop chan struct{}) {
+		for {
+			if err := watcher.Check(); err != nil && watcher.OnError != nil {
+				watcher.OnError(err)
+			}
+
+			if !wait(watcher.Clock, stop, Jitter(watcher.Interval, watcher.Jitter)) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package steam
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type overviewTransport map[string]string
+
+func (transport overviewTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := transport[req.URL.Query().Get("market_hash_name")]
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+type notifierFunc func(*Notification) error
+
+func (f notifierFunc) Notify(notification *Notification) error {
+	return f(notification)
+}
+
+func TestPriceWatcherCheckCollectsErrors(t *testing.T) {
+	session := NewSession(&http.Client{Transport: overviewTransport{
+		"cheap":  `{"success":true,"lowest_price":"$1.00"}`,
+		"pricey": `{"success":true,"lowest_price":"$90.00"}`,
+	}}, "")
+
+	errNotify := errors.New("notify failed")
+	var alerts []string
+
+	watcher := session.NewPriceWatcher(0, nil)
+	watcher.Watches = []*PriceWatch{
+		{MarketHashName: "missing", Below: 100},
+		{MarketHashName: "cheap", Below: 200},
+		{MarketHashName: "pricey", Above: 5000},
+	}
+	watcher.Notifier = notifierFunc(func(notification *Notification) error {
+		alerts = append(alerts, notification.Title)
+		if notification.Title == "cheap" {
+			return errNotify
+		}
+		return nil
+	})
+
+	err := watcher.Check()
+	errs, ok := err.(PriceWatchErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("err = %v, want 2 PriceWatchErrors", err)
+	}
+
+	if errs[0].Watch.MarketHashName != "missing" || errs[1].Watch.MarketHashName != "cheap" || !errors.Is(errs[1], errNotify) {
+		t.Errorf("errs = %v", errs)
+	}
+
+	if strings.Join(alerts, ",") != "cheap,pricey" {
+		t.Errorf("alerts = %v, want every crossed watch notified", alerts)
+	}
+
+	// The failed notification is retried, the delivered one isn't.
+	alerts = nil
+	watcher.Check()
+	if strings.Join(alerts, ",") != "cheap" {
+		t.Errorf("alerts = %v, want cheap again", alerts)
+	}
+}
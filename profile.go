@@ -1,7 +1,6 @@
 package steam
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -82,7 +81,7 @@ type Friend struct {
 }
 
 func (session *Session) GetProfileURL() (string, error) {
-	tmpClient := http.Client{Jar: session.client.Jar}
+	tmpClient := http.Client{Jar: session.client.Jar, Transport: session.client.Transport}
 
 	/* We do not follow redirect, we want to know where it'd redirect us.  */
 	tmpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
@@ -170,13 +169,6 @@ func (session *Session) GetPlayerSummaries(steamids string) ([]*PlayerSummary, e
 		"key":      {session.apiKey},
 		"steamids": {steamids},
 	}.Encode())
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return nil, err
-	}
 
 	type Players struct {
 		Summaries []*PlayerSummary `json:"players"`
@@ -187,7 +179,7 @@ func (session *Session) GetPlayerSummaries(steamids string) ([]*PlayerSummary, e
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return nil, err
 	}
 
@@ -202,20 +194,13 @@ func (session *Session) GetOwnedGames(sid SteamID, freeGames bool, appInfo bool)
 		"include_appinfo":           {strconv.FormatBool(appInfo)},
 		"include_played_free_games": {strconv.FormatBool(freeGames)},
 	}.Encode())
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return nil, err
-	}
 
 	type Response struct {
 		Inner *OwnedGamesResponse `json:"response"`
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return nil, err
 	}
 
@@ -227,20 +212,13 @@ func (session *Session) GetPlayerBans(steamids string) ([]*PlayerBan, error) {
 		"key":      {session.apiKey},
 		"steamids": {steamids},
 	}.Encode())
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return nil, err
-	}
 
 	type Response struct {
 		Inner []*PlayerBan `json:"players"`
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return nil, err
 	}
 
@@ -253,13 +231,6 @@ func (session *Session) GetFriends(sid SteamID) ([]*Friend, error) {
 		"steamid": {sid.ToString()},
 		"format":  {"json"},
 	}.Encode())
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return nil, err
-	}
 
 	type Friends struct {
 		Friends []*Friend `json:"friends"`
@@ -270,7 +241,7 @@ func (session *Session) GetFriends(sid SteamID) ([]*Friend, error) {
 	}
 
 	var friendsList FriendsList
-	if err = json.NewDecoder(resp.Body).Decode(&friendsList); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &friendsList); err != nil {
 		return nil, err
 	}
 
@@ -282,13 +253,6 @@ func (session *Session) ResolveVanityURL(vanityURL string) (uint64, error) {
 		"key":       {session.apiKey},
 		"vanityurl": {vanityURL},
 	}.Encode())
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return 0, err
-	}
 
 	type VanityData struct {
 		Success uint32 `json:"success"`
@@ -300,7 +264,9 @@ func (session *Session) ResolveVanityURL(vanityURL string) (uint64, error) {
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); errors.Is(err, ErrEResultNoMatch) {
+		return 0, ErrCannotFindVanityMatch
+	} else if err != nil {
 		return 0, err
 	}
 
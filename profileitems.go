@@ -0,0 +1,94 @@
+package steam
+
+import (
+	"net/url"
+	"strconv"
+)
+
+const (
+	apiGetProfileItemsOwned    = "https://api.steampowered.com/IPlayerService/GetProfileItemsOwned/v1/?"
+	apiGetProfileItemsEquipped = "https://api.steampowered.com/IPlayerService/GetProfileItemsEquipped/v1/?"
+	apiPlayerService           = "https://api.steampowered.com/IPlayerService/"
+)
+
+// What EquipProfileItem can equip, the names of the IPlayerService methods.
+const (
+	ProfileItemBackground     = "SetProfileBackground"
+	ProfileItemMiniBackground = "SetMiniProfileBackground"
+	ProfileItemAvatarFrame    = "SetAvatarFrame"
+	ProfileItemAnimatedAvatar = "SetAnimatedAvatar"
+)
+
+// ProfileItem is a profile customization, the images are relative to the
+// community CDN and the movies are only set for animated items.
+type ProfileItem struct {
+	CommunityItemID uint64 `json:"communityitemid,string"`
+	ImageSmall      string `json:"image_small"`
+	ImageLarge      string `json:"image_large"`
+	Name            string `json:"name"`
+	Title           string `json:"item_title"`
+	Description     string `json:"item_description"`
+	AppID           uint32 `json:"appid"`
+	Type            int    `json:"item_type"`
+	Class           int    `json:"item_class"`
+	MovieWebM       string `json:"movie_webm"`
+	MovieMP4        string `json:"movie_mp4"`
+	EquippedFlags   uint32 `json:"equipped_flags"`
+}
+
+type ProfileItemsOwned struct {
+	Backgrounds     []*ProfileItem `json:"profile_backgrounds"`
+	MiniBackgrounds []*ProfileItem `json:"mini_profile_backgrounds"`
+	AvatarFrames    []*ProfileItem `json:"avatar_frames"`
+	AnimatedAvatars []*ProfileItem `json:"animated_avatars"`
+	Modifiers       []*ProfileItem `json:"profile_modifiers"`
+}
+
+// ProfileItemsEquipped items are nil when nothing is equipped.
+type ProfileItemsEquipped struct {
+	Background     *ProfileItem `json:"profile_background"`
+	MiniBackground *ProfileItem `json:"mini_profile_background"`
+	AvatarFrame    *ProfileItem `json:"avatar_frame"`
+	AnimatedAvatar *ProfileItem `json:"animated_avatar"`
+	Modifier       *ProfileItem `json:"profile_modifier"`
+}
+
+// GetProfileItemsOwned returns the profile items of our account.
+func (session *Session) GetProfileItemsOwned() (*ProfileItemsOwned, error) {
+	resp, err := session.client.Get(apiGetProfileItemsOwned + url.Values{
+		"access_token": {session.oauth.Token},
+		"language":     {session.language},
+	}.Encode())
+
+	owned := &ProfileItemsOwned{}
+	if err = session.decodeServiceResponse(resp, err, owned); err != nil {
+		return nil, err
+	}
+
+	return owned, nil
+}
+
+func (session *Session) GetProfileItemsEquipped(sid SteamID) (*ProfileItemsEquipped, error) {
+	resp, err := session.client.Get(apiGetProfileItemsEquipped + url.Values{
+		"key":      {session.apiKey},
+		"steamid":  {sid.ToString()},
+		"language": {session.language},
+	}.Encode())
+
+	equipped := &ProfileItemsEquipped{}
+	if err = session.decodeServiceResponse(resp, err, equipped); err != nil {
+		return nil, err
+	}
+
+	return equipped, nil
+}
+
+// EquipProfileItem equips @communityItemID as @kind (ProfileItem*), 0 unequips
+// what is equipped.
+func (session *Session) EquipProfileItem(kind string, communityItemID uint64) error {
+	resp, err := session.client.PostForm(apiPlayerService+kind+"/v1/?access_token="+url.QueryEscape(session.oauth.Token), url.Values{
+		"communityitemid": {strconv.FormatUint(communityItemID, 10)},
+	})
+
+	return session.decodeServiceResponse(resp, err, &struct{}{})
+}
@@ -0,0 +1,96 @@
+package steam
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type ProfileXMLGame struct {
+	Name          string  `xml:"gameName"`
+	Link          string  `xml:"gameLink"`
+	Icon          string  `xml:"gameIcon"`
+	Logo          string  `xml:"gameLogo"`
+	HoursPlayed   float64 `xml:"hoursPlayed"`
+	HoursOnRecord string  `xml:"hoursOnRecord"` // may contain thousands separators
+	StatsName     string  `xml:"statsName"`
+}
+
+// ProfileXML is what the community returns for ?xml=1, no login
+// nor API key is needed for it.
+type ProfileXML struct {
+	SteamID          SteamID           `xml:"steamID64"`
+	PersonaName      string            `xml:"steamID"`
+	OnlineState      string            `xml:"onlineState"`
+	StateMessage     string            `xml:"stateMessage"`
+	PrivacyState     string            `xml:"privacyState"`
+	VisibilityState  uint32            `xml:"visibilityState"`
+	AvatarURL        string            `xml:"avatarIcon"`
+	AvatarMediumURL  string            `xml:"avatarMedium"`
+	AvatarFullURL    string            `xml:"avatarFull"`
+	VACBanned        bool              `xml:"vacBanned"`
+	TradeBanState    string            `xml:"tradeBanState"`
+	IsLimitedAccount bool              `xml:"isLimitedAccount"`
+	CustomURL        string            `xml:"customURL"`
+	MemberSince      string            `xml:"memberSince"`
+	Location         string            `xml:"location"`
+	RealName         string            `xml:"realname"`
+	Summary          string            `xml:"summary"`
+	MostPlayedGames  []*ProfileXMLGame `xml:"mostPlayedGames>mostPlayedGame"`
+	Error            string            `xml:"error"`
+}
+
+func (session *Session) GetProfileXML(sid SteamID) (*ProfileXML, error) {
+	resp, err := session.client.Get("https://steamcommunity.com/profiles/" + sid.ToString() + "/?xml=1")
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	profile := &ProfileXML{}
+	if err = xml.NewDecoder(resp.Body).Decode(profile); err != nil {
+		return nil, err
+	}
+
+	if len(profile.Error) != 0 {
+		return nil, errors.New(profile.Error)
+	}
+
+	return profile, nil
+}
+
+// ToPlayerSummary fills in what the XML profile has of a player summary, this is
+// meant as a fallback for GetPlayerSummaries when there's no API key at hand.
+func (profile *ProfileXML) ToPlayerSummary() *PlayerSummary {
+	summary := &PlayerSummary{
+		SteamID:         profile.SteamID,
+		VisibilityState: profile.VisibilityState,
+		PersonaName:     profile.PersonaName,
+		RealName:        profile.RealName,
+		AvatarURL:       profile.AvatarURL,
+		AvatarMediumURL: profile.AvatarMediumURL,
+		AvatarFullURL:   profile.AvatarFullURL,
+		ProfileURL:      "https://steamcommunity.com/profiles/" + profile.SteamID.ToString() + "/",
+	}
+
+	if len(profile.CustomURL) != 0 {
+		summary.ProfileURL = "https://steamcommunity.com/id/" + profile.CustomURL + "/"
+	}
+
+	switch profile.OnlineState {
+	case "online", "in-game":
+		summary.PersonaState = PersonaStateOnline
+	default:
+		summary.PersonaState = PersonaStateOffline
+	}
+
+	return summary
+}
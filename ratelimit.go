@@ -0,0 +1,295 @@
+package steam
+
+import (
+	"container/heap"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	PriorityLow    = iota // Background work, e.g. price history sweeps
+	PriorityNormal        // Page and API reads
+	PriorityHigh          // Actions, e.g. accepting a trade or buying a listing
+)
+
+type priorityContextKey struct{}
+
+// WithPriority sets the priority the requests made with @ctx are given a slot of
+// the rate limiter with, instead of the default one (see requestPriority).
+func WithPriority(ctx context.Context, priority int) context.Context {
+	return context.WithValue(ctx, priorityContextKey{}, priority)
+}
+
+// requestPriority is the priority set with WithPriority, otherwise POST requests
+// (which are the actions) are high and price history is low.
+func requestPriority(req *http.Request) int {
+	if priority, ok := req.Context().Value(priorityContextKey{}).(int); ok {
+		return priority
+	}
+
+	if req.Method == http.MethodPost {
+		return PriorityHigh
+	}
+
+	if strings.HasPrefix(req.URL.Path, "/market/pricehistory/") {
+		return PriorityLow
+	}
+
+	return PriorityNormal
+}
+
+type rateWaiter struct {
+	priority int
+	seq      uint64
+	ready    chan struct{}
+	index    int // In the heap, -1 once let through or removed
+}
+
+// rateWaiters is a heap giving the highest priority first, then the oldest.
+type rateWaiters []*rateWaiter
+
+func (w rateWaiters) Len() int { return len(w) }
+func (w rateWaiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index, w[j].index = i, j
+}
+func (w rateWaiters) Less(i, j int) bool {
+	if w[i].priority != w[j].priority {
+		return w[i].priority > w[j].priority
+	}
+	return w[i].seq < w[j].seq
+}
+func (w *rateWaiters) Push(x interface{}) {
+	waiter := x.(*rateWaiter)
+	waiter.index = len(*w)
+	*w = append(*w, waiter)
+}
+func (w *rateWaiters) Pop() interface{} {
+	old := *w
+	waiter := old[len(old)-1]
+	waiter.index = -1
+	*w = old[:len(old)-1]
+	return waiter
+}
+
+// RateLimiter spaces requests at least Interval apart, it can be shared by
+// several sessions (e.g. same IP) and is safe for concurrent use.
+// Requests waiting for a slot are queued by priority.
+type RateLimiter struct {
+	interval    time.Duration
+	next        time.Time
+	waiters     rateWaiters
+	seq         uint64
+	dispatching bool
+	mutex       sync.Mutex
+}
+
+func NewRateLimiter(interval time.Duration) *RateLimiter {
+	return &RateLimiter{interval: interval}
+}
+
+// Wait blocks until the next request is allowed, with PriorityNormal.
+func (limiter *RateLimiter) Wait() {
+	limiter.WaitPriority(PriorityNormal)
+}
+
+// WaitPriority blocks until the next request is allowed, no waiter of a lower
+// @priority is let through before.
+func (limiter *RateLimiter) WaitPriority(priority int) {
+	limiter.WaitContext(context.Background(), priority)
+}
+
+// WaitContext is WaitPriority giving up once @ctx is done, in which case the
+// error of @ctx is returned and the place in the queue is given up.
+func (limiter *RateLimiter) WaitContext(ctx context.Context, priority int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	limiter.mutex.Lock()
+	now := time.Now()
+	if len(limiter.waiters) == 0 && !now.Before(limiter.next) {
+		limiter.next = now.Add(limiter.interval)
+		limiter.mutex.Unlock()
+		return nil
+	}
+
+	waiter := &rateWaiter{
+		priority: priority,
+		seq:      limiter.seq,
+		ready:    make(chan struct{}),
+	}
+	limiter.seq++
+	heap.Push(&limiter.waiters, waiter)
+
+	if !limiter.dispatching {
+		limiter.dispatching = true
+		go limiter.dispatch()
+	}
+	limiter.mutex.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Let through in the meantime, the slot is used up anyway.
+	if waiter.index != -1 {
+		heap.Remove(&limiter.waiters, waiter.index)
+	}
+
+	return ctx.Err()
+}
+
+// dispatch lets the waiters through one at a time, until there are none left.
+func (limiter *RateLimiter) dispatch() {
+	for {
+		limiter.mutex.Lock()
+		if len(limiter.waiters) == 0 {
+			limiter.dispatching = false
+			limiter.mutex.Unlock()
+			return
+		}
+
+		now := time.Now()
+		if wait := limiter.next.Sub(now); wait > 0 {
+			limiter.mutex.Unlock()
+			time.Sleep(wait)
+			continue
+		}
+
+		waiter := heap.Pop(&limiter.waiters).(*rateWaiter)
+		limiter.next = now.Add(limiter.interval)
+		limiter.mutex.Unlock()
+
+		close(waiter.ready)
+	}
+}
+
+// SetRateLimiter makes every request of the session wait on @limiter, nil disables it.
+func (session *Session) SetRateLimiter(limiter *RateLimiter) {
+	session.limiter = limiter
+}
+
+// sessionTransport is where requests made by the session pass through.
+type sessionTransport struct {
+	session *Session
+	base    http.RoundTripper
+}
+
+func (transport *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet && req.Body == nil {
+		if cache := transport.session.validators; cache != nil {
+			return transport.conditional(req, cache)
+		}
+	}
+
+	return transport.forward(req)
+}
+
+// forward sends @req, sharing it with the identical ones in flight if enabled.
+func (transport *sessionTransport) forward(req *http.Request) (*http.Response, error) {
+	if transport.session.dedupeGets && req.Method == http.MethodGet && req.Body == nil {
+		return transport.shared(req)
+	}
+
+	return transport.roundTrip(req)
+}
+
+func (transport *sessionTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	req, traceID := withTrace(req)
+
+	// Check the breaker first so that failing fast doesn't use the rate limit.
+	breaker := transport.session.breaker
+	endpoint := req.URL.Host + req.URL.Path
+	if breaker != nil {
+		if err := breaker.allow(endpoint); err != nil {
+			return nil, &TraceError{TraceID: traceID, Err: err}
+		}
+	}
+
+	resp, err := transport.send(req)
+	if breaker != nil {
+		breaker.record(endpoint, requestFailed(resp, err))
+	}
+
+	if sink := transport.session.debugSink; sink != nil && (err != nil || resp.StatusCode >= http.StatusBadRequest) {
+		dump := &RequestDump{
+			TraceID: traceID,
+			Time:    time.Now(),
+			Request: dumpRequest(req),
+			Err:     err,
+		}
+		if err == nil {
+			dump.Response = dumpResponse(resp)
+		}
+		sink.Dump(dump)
+	}
+
+	if err != nil {
+		return nil, &TraceError{TraceID: traceID, Err: err}
+	}
+
+	resp.Request = req
+	return resp, nil
+}
+
+// send waits for the concurrency slots and the rate limiter then sends @req.
+func (transport *sessionTransport) send(req *http.Request) (*http.Response, error) {
+	session := transport.session
+
+	var release func()
+	if limits := session.concurrency; limits != nil {
+		var err error
+		if release, err = limits.acquire(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if limiter := session.limiter; limiter != nil {
+		if err := limiter.WaitContext(req.Context(), requestPriority(req)); err != nil {
+			if release != nil {
+				release()
+			}
+			return nil, err
+		}
+	}
+
+	base := transport.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := session.timeouts.roundTrip(req, base.RoundTrip)
+	if observer := session.responseObserver; observer != nil && err == nil {
+		observer(newResponseMeta(req, resp, time.Since(start)))
+	}
+	if release != nil {
+		if err != nil {
+			release()
+		} else {
+			resp.Body = &closeHookBody{ReadCloser: resp.Body, onClose: release}
+		}
+	}
+
+	return resp, err
+}
+
+// setClient keeps a copy of @client going through the session transport,
+// so that the client given by the user is left untouched.
+func (session *Session) setClient(client *http.Client) {
+	c := *client
+	c.Transport = &sessionTransport{
+		session: session,
+		base:    client.Transport,
+	}
+	session.client = &c
+}
@@ -0,0 +1,49 @@
+package steam
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWaitContextCancelled(t *testing.T) {
+	limiter := NewRateLimiter(time.Hour)
+	limiter.Wait() // Takes the first slot, the next one is an hour away.
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.WaitContext(ctx, PriorityNormal); err != context.DeadlineExceeded {
+		t.Fatalf("WaitContext error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	limiter.mutex.Lock()
+	waiting := len(limiter.waiters)
+	limiter.mutex.Unlock()
+
+	if waiting != 0 {
+		t.Fatalf("%d waiter(s) left in the queue after cancelling", waiting)
+	}
+}
+
+func TestRateLimiterPriority(t *testing.T) {
+	limiter := NewRateLimiter(20 * time.Millisecond)
+	limiter.Wait()
+
+	order := make(chan int, 2)
+	started := make(chan struct{}, 2)
+	for _, priority := range []int{PriorityLow, PriorityHigh} {
+		go func(priority int) {
+			started <- struct{}{}
+			limiter.WaitPriority(priority)
+			order <- priority
+		}(priority)
+		<-started
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	if first := <-order; first != PriorityHigh {
+		t.Fatalf("first let through has priority %d, want %d", first, PriorityHigh)
+	}
+	<-order
+}
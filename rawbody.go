@@ -0,0 +1,62 @@
+package steam
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+)
+
+// RawResponse is embedded by the responses able to keep the body they were
+// decoded from, see SetKeepRawBody.
+type RawResponse struct {
+	RawBody []byte `json:"-"`
+}
+
+func (response *RawResponse) setRawBody(body []byte) {
+	response.RawBody = body
+}
+
+type rawBodyHolder interface {
+	setRawBody(body []byte)
+}
+
+// SetKeepRawBody makes the responses embedding RawResponse keep the JSON they were
+// decoded from, for debugging or to read fields this package doesn't know about.
+func (session *Session) SetKeepRawBody(keep bool) {
+	session.keepRawBody = keep
+}
+
+// SetStrictDecoding makes decoding fail on fields the response types don't have,
+// which is a way to notice that Steam changed a payload.  Most types only have
+// the fields that matter, so this is only meant for diagnosis.
+func (session *Session) SetStrictDecoding(strict bool) {
+	session.strictDecoding = strict
+}
+
+// decodeJSON decodes @r into @v following the session options.
+func (session *Session) decodeJSON(r io.Reader, v interface{}) error {
+	if !session.keepRawBody && !session.strictDecoding {
+		return json.NewDecoder(r).Decode(v)
+	}
+
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if session.strictDecoding {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err = decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if holder, ok := v.(rawBodyHolder); ok && session.keepRawBody {
+		holder.setRawBody(body)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Purchase result details (EPurchaseResultDetail) Steam answers redeeming with.
+const (
+	PurchaseResultNoDetail                = 0
+	PurchaseResultAlreadyPurchased        = 9
+	PurchaseResultRestrictedCountry       = 13
+	PurchaseResultBadActivationCode       = 14
+	PurchaseResultDuplicateActivationCode = 15
+	PurchaseResultDoesNotOwnRequiredApp   = 24
+	PurchaseResultRateLimited             = 53
+)
+
+var (
+	ErrCannotRedeem        = errors.New("unable to redeem code")
+	ErrAlreadyOwned        = errors.New("product is already owned")
+	ErrRegionLocked        = errors.New("code cannot be redeemed in this region")
+	ErrInvalidCode         = errors.New("code is not valid")
+	ErrCodeAlreadyRedeemed = errors.New("code was already redeemed")
+	ErrRequiresBaseGame    = errors.New("product requires a game that is not owned")
+	ErrRedeemRateLimited   = errors.New("too many redeem attempts, try again later")
+)
+
+var purchaseResultErrors = map[int]error{
+	PurchaseResultAlreadyPurchased:        ErrAlreadyOwned,
+	PurchaseResultRestrictedCountry:       ErrRegionLocked,
+	PurchaseResultBadActivationCode:       ErrInvalidCode,
+	PurchaseResultDuplicateActivationCode: ErrCodeAlreadyRedeemed,
+	PurchaseResultDoesNotOwnRequiredApp:   ErrRequiresBaseGame,
+	PurchaseResultRateLimited:             ErrRedeemRateLimited,
+}
+
+// RedeemError Detail is the PurchaseResult* Steam gave, errors.Is works with the
+// Err* above for the known ones and ErrCannotRedeem for the others.
+type RedeemError struct {
+	Detail int
+}
+
+func (err *RedeemError) Error() string {
+	return fmt.Sprintf("%v (purchase result %d)", err.Unwrap(), err.Detail)
+}
+
+func (err *RedeemError) Unwrap() error {
+	if known, ok := purchaseResultErrors[err.Detail]; ok {
+		return known
+	}
+
+	return ErrCannotRedeem
+}
+
+type WalletCodeResult struct {
+	Amount     int64  `json:"amount"`
+	Currency   int    `json:"currencycode"`
+	NewBalance string `json:"formattednewwalletbalance"`
+}
+
+type ProductKeyPackage struct {
+	SubID       uint64 `json:"packageid"`
+	Description string `json:"line_item_description"`
+}
+
+// ProductKeyResult Packages are the packages the key gave.
+type ProductKeyResult struct {
+	Packages []*ProductKeyPackage
+}
+
+func (session *Session) storeAccountAction(action string, values url.Values, response interface{}) error {
+	values.Set("sessionid", session.sessionID)
+	resp, err := session.client.PostForm("https://store.steampowered.com/account/"+action+"/", values)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	return session.decodeJSON(resp.Body, response)
+}
+
+// RedeemWalletCode adds the funds of a wallet code to our wallet.  This is a
+// store request, see PrepareForSteamStore.
+func (session *Session) RedeemWalletCode(code string) (*WalletCodeResult, error) {
+	type Response struct {
+		WalletCodeResult
+
+		Success int `json:"success"`
+		Detail  int `json:"detail"`
+	}
+
+	var response Response
+	if err := session.storeAccountAction("ajaxredeemwalletcode", url.Values{"wallet_code": {code}}, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Success != 1 {
+		return nil, &RedeemError{Detail: response.Detail}
+	}
+
+	return &response.WalletCodeResult, nil
+}
+
+// RegisterProductKey activates a CD key on the account.  This is a store request,
+// see PrepareForSteamStore.
+func (session *Session) RegisterProductKey(key string) (*ProductKeyResult, error) {
+	type Response struct {
+		Success     int `json:"success"`
+		Detail      int `json:"purchase_result_details"`
+		ReceiptInfo struct {
+			LineItems []*ProductKeyPackage `json:"line_items"`
+		} `json:"purchase_receipt_info"`
+	}
+
+	var response Response
+	if err := session.storeAccountAction("ajaxregisterkey", url.Values{"product_key": {key}}, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Success != 1 {
+		return nil, &RedeemError{Detail: response.Detail}
+	}
+
+	return &ProductKeyResult{Packages: response.ReceiptInfo.LineItems}, nil
+}
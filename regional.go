@@ -0,0 +1,74 @@
+package steam
+
+import "sync"
+
+// MarketRegion is the country and currency listings are asked for.
+type MarketRegion struct {
+	Country    string
+	CurrencyID string
+}
+
+// RegionalListings is a row of CompareMarketRegions, prices are in cents of the
+// region currency, Converted ones in cents of the base currency (0 without rates).
+type RegionalListings struct {
+	Region     MarketRegion
+	Listings   *MarketListingsResponse
+	Lowest     uint64
+	Median     uint64
+	Converted  float64
+	TotalCount int
+	Err        error
+}
+
+// CompareMarketRegions fetches the first @count listings of an item in every
+// region concurrently (the session limits still apply) and returns one row per
+// region, in order.  @fx may be nil, otherwise the lowest prices are converted to
+// @baseCurrency (an ISO code) for comparison.  A failing region only sets Err of
+// its row.
+func (session *Session) CompareMarketRegions(
+	appID uint64,
+	marketHashName string,
+	regions []MarketRegion,
+	count int,
+	baseCurrency string,
+	fx FXRateSource,
+) []*RegionalListings {
+	rows := make([]*RegionalListings, len(regions))
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		rows[i] = &RegionalListings{Region: region}
+
+		wg.Add(1)
+		go func(row *RegionalListings) {
+			defer wg.Done()
+
+			listings, err := session.GetMarketListings(appID, marketHashName, row.Region.Country, row.Region.CurrencyID, 0, count)
+			if err != nil {
+				row.Err = err
+				return
+			}
+
+			row.Listings = listings
+			row.TotalCount = listings.TotalCount
+			if len(listings.Listings) == 0 {
+				return
+			}
+
+			row.Lowest = listings.Listings[0].BuyerPays()
+			row.Median = listings.Listings[len(listings.Listings)/2].BuyerPays()
+
+			if code, ok := CurrencyCodes[row.Region.CurrencyID]; ok && fx != nil {
+				rate, err := fx.Rate(code, baseCurrency)
+				if err != nil {
+					row.Err = err
+					return
+				}
+
+				row.Converted = float64(row.Lowest) * rate
+			}
+		}(rows[i])
+	}
+
+	wg.Wait()
+	return rows
+}
@@ -0,0 +1,59 @@
+package steam
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMeta describes a response the session got, Latency is the time from
+// sending the request to getting the headers (waiting on the rate limiter and
+// concurrency slots left out).  EResult is the X-eresult header, 0 if missing.
+type ResponseMeta struct {
+	TraceID    string
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	EResult    int
+	Latency    time.Duration
+	Time       time.Time
+}
+
+// ResponseObserver is given the metadata of every response, e.g. to throttle on
+// latency or on the 429s of an endpoint.  It's called from the request goroutine
+// before the body is read, so it must not block for long.
+type ResponseObserver func(meta *ResponseMeta)
+
+// SetResponseObserver makes @observer see every response, nil disables it.
+func (session *Session) SetResponseObserver(observer ResponseObserver) {
+	session.responseObserver = observer
+}
+
+// responseEResult reads the X-eresult header of @resp, 0 if it has none.
+func responseEResult(resp *http.Response) int {
+	result, err := strconv.Atoi(resp.Header.Get("X-eresult"))
+	if err != nil {
+		return 0
+	}
+
+	return result
+}
+
+func newResponseMeta(req *http.Request, resp *http.Response, latency time.Duration) *ResponseMeta {
+	u := *req.URL
+	query := u.Query()
+	scrubDumpValues(query)
+	u.RawQuery = query.Encode()
+
+	return &ResponseMeta{
+		TraceID:    TraceID(req.Context()),
+		Method:     req.Method,
+		URL:        u.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		EResult:    responseEResult(resp),
+		Latency:    latency,
+		Time:       time.Now(),
+	}
+}
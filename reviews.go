@@ -0,0 +1,205 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The first cursor of GetAppReviews.
+const FirstReviewCursor = "*"
+
+var (
+	ErrCannotLoadReviews = errors.New("unable to load reviews")
+	ErrCannotPostReview  = errors.New("unable to post review")
+)
+
+// ReviewFilter zero values use the Steam defaults, see the appreviews
+// documentation for the accepted values.
+type ReviewFilter struct {
+	Filter       string // recent, updated or all (by helpfulness)
+	Language     string // all or a language name
+	DayRange     int    // for Filter all only
+	ReviewType   string // all, positive or negative
+	PurchaseType string // all, non_steam_purchase or steam
+	PerPage      int    // up to 100
+}
+
+type ReviewAuthor struct {
+	SteamID              uint64 `json:"steamid,string"`
+	GamesOwned           int    `json:"num_games_owned"`
+	Reviews              int    `json:"num_reviews"`
+	PlaytimeForever      int    `json:"playtime_forever"`
+	PlaytimeLastTwoWeeks int    `json:"playtime_last_two_weeks"`
+	PlaytimeAtReview     int    `json:"playtime_at_review"`
+	LastPlayed           int64  `json:"last_played"`
+}
+
+// reviewScore is sent either as a number or a string.
+type reviewScore float64
+
+func (score *reviewScore) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseFloat(strings.Trim(string(data), `"`), 64)
+	if err != nil {
+		return err
+	}
+
+	*score = reviewScore(value)
+	return nil
+}
+
+type AppReview struct {
+	ID                uint64        `json:"recommendationid,string"`
+	Author            *ReviewAuthor `json:"author"`
+	Language          string        `json:"language"`
+	Text              string        `json:"review"`
+	Created           int64         `json:"timestamp_created"`
+	Updated           int64         `json:"timestamp_updated"`
+	VotedUp           bool          `json:"voted_up"`
+	VotesUp           int           `json:"votes_up"`
+	VotesFunny        int           `json:"votes_funny"`
+	WeightedVoteScore reviewScore   `json:"weighted_vote_score"`
+	Comments          int           `json:"comment_count"`
+	SteamPurchase     bool          `json:"steam_purchase"`
+	ReceivedForFree   bool          `json:"received_for_free"`
+	EarlyAccess       bool          `json:"written_during_early_access"`
+}
+
+// ReviewSummary is only complete in the first page.
+type ReviewSummary struct {
+	Reviews         int    `json:"num_reviews"`
+	ReviewScore     int    `json:"review_score"`
+	ReviewScoreDesc string `json:"review_score_desc"`
+	TotalPositive   int    `json:"total_positive"`
+	TotalNegative   int    `json:"total_negative"`
+	TotalReviews    int    `json:"total_reviews"`
+}
+
+// AppReviewsPage Cursor is what to pass to get the next page, there are no more
+// once Reviews is empty.
+type AppReviewsPage struct {
+	Success int            `json:"success"`
+	Summary *ReviewSummary `json:"query_summary"`
+	Reviews []*AppReview   `json:"reviews"`
+	Cursor  string         `json:"cursor"`
+}
+
+// GetAppReviews returns a page of the reviews of @appID, start with FirstReviewCursor.
+func (session *Session) GetAppReviews(appID uint32, filter *ReviewFilter, cursor string) (*AppReviewsPage, error) {
+	params := url.Values{
+		"json":   {"1"},
+		"cursor": {cursor},
+	}
+
+	if filter != nil {
+		set := func(key, value string) {
+			if len(value) != 0 {
+				params.Set(key, value)
+			}
+		}
+
+		set("filter", filter.Filter)
+		set("language", filter.Language)
+		set("review_type", filter.ReviewType)
+		set("purchase_type", filter.PurchaseType)
+		if filter.DayRange != 0 {
+			params.Set("day_range", strconv.Itoa(filter.DayRange))
+		}
+		if filter.PerPage != 0 {
+			params.Set("num_per_page", strconv.Itoa(filter.PerPage))
+		}
+	}
+
+	resp, err := session.client.Get("https://store.steampowered.com/appreviews/" + strconv.FormatUint(uint64(appID), 10) + "?" + params.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	page := &AppReviewsPage{}
+	if err = session.decodeJSON(resp.Body, page); err != nil {
+		return nil, err
+	}
+
+	if page.Success != 1 {
+		return nil, ErrCannotLoadReviews
+	}
+
+	return page, nil
+}
+
+// ReviewPost is our review of an app, posting it again updates it.
+type ReviewPost struct {
+	AppID                uint32
+	Text                 string
+	RatedUp              bool
+	Public               bool
+	Language             string // defaults to the session language
+	ReceivedCompensation bool
+	DisableComments      bool
+}
+
+// PostReview posts or updates our review.  This is a store request, see
+// PrepareForSteamStore.
+func (session *Session) PostReview(review *ReviewPost) error {
+	language := review.Language
+	if len(language) == 0 {
+		language = session.language
+	}
+
+	boolValue := func(b bool) string {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+
+	appID := strconv.FormatUint(uint64(review.AppID), 10)
+	resp, err := session.client.PostForm("https://store.steampowered.com/friends/recommendgame", url.Values{
+		"appid":                 {appID},
+		"steamworksappid":       {appID},
+		"comment":               {review.Text},
+		"rated_up":              {boolValue(review.RatedUp)},
+		"is_public":             {boolValue(review.Public)},
+		"language":              {language},
+		"received_compensation": {boolValue(review.ReceivedCompensation)},
+		"disable_comments":      {boolValue(review.DisableComments)},
+		"sessionid":             {session.sessionID},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Response struct {
+		Success bool `json:"success"`
+	}
+
+	var response Response
+	if err = session.decodeJSON(resp.Body, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return ErrCannotPostReview
+	}
+
+	return nil
+}
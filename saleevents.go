@@ -0,0 +1,106 @@
+package steam
+
+import (
+	"errors"
+	"net/url"
+)
+
+const (
+	apiSaleCanClaimItem = "https://api.steampowered.com/ISaleItemRewardsService/CanClaimItem/v1/?"
+	apiSaleClaimItem    = "https://api.steampowered.com/ISaleItemRewardsService/ClaimItem/v1/"
+)
+
+var (
+	ErrSaleEventsDisabled = errors.New("sale event requests are not enabled")
+	ErrNothingToClaim     = errors.New("no sale event reward to claim")
+)
+
+// SaleRewardItem is a community item given during sales (sticker, badge token,
+// profile item...), Type is the community item type as in LoyaltyReward.
+type SaleRewardItem struct {
+	AppID              uint32 `json:"appid"`
+	DefID              uint32 `json:"defid"`
+	Type               int    `json:"type"`
+	CommunityItemClass uint32 `json:"community_item_class"`
+	CommunityItemType  uint32 `json:"community_item_type"`
+	PointCost          uint64 `json:"point_cost,string"`
+	TimeCreated        int64  `json:"timestamp_created"`
+	TimeUpdated        int64  `json:"timestamp_updated"`
+	Active             bool   `json:"active"`
+}
+
+// SaleRewardStatus tells whether today's reward can be claimed, NextClaimTime is
+// when the next one is available.
+type SaleRewardStatus struct {
+	CanClaim      bool            `json:"can_claim"`
+	NextClaimTime int64           `json:"next_claim_time"`
+	Item          *SaleRewardItem `json:"reward_item"`
+}
+
+type SaleRewardClaim struct {
+	CommunityItemID uint64          `json:"communityitemid,string"`
+	NextClaimTime   int64           `json:"next_claim_time"`
+	Item            *SaleRewardItem `json:"reward_item"`
+}
+
+// SetSaleEvents enables the seasonal sale requests (GetSaleRewardStatus,
+// ClaimSaleReward).  They only work while a sale is on and change from one sale
+// to the next, so they are off unless asked for.
+func (session *Session) SetSaleEvents(enabled bool) {
+	session.saleEvents = enabled
+}
+
+// GetSaleRewardStatus tells whether the daily sale reward (e.g. a sticker) can be
+// claimed.
+func (session *Session) GetSaleRewardStatus() (*SaleRewardStatus, error) {
+	if !session.saleEvents {
+		return nil, ErrSaleEventsDisabled
+	}
+
+	resp, err := session.client.Get(apiSaleCanClaimItem + url.Values{
+		"access_token": {session.oauth.Token},
+		"language":     {session.language},
+	}.Encode())
+
+	status := &SaleRewardStatus{}
+	if err = session.decodeServiceResponse(resp, err, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// ClaimSaleReward claims the daily sale reward, ErrNothingToClaim is returned if
+// it was already claimed.
+func (session *Session) ClaimSaleReward() (*SaleRewardClaim, error) {
+	if !session.saleEvents {
+		return nil, ErrSaleEventsDisabled
+	}
+
+	resp, err := session.client.PostForm(apiSaleClaimItem+"?access_token="+url.QueryEscape(session.oauth.Token), url.Values{
+		"language": {session.language},
+	})
+
+	claim := &SaleRewardClaim{}
+	if err = session.decodeServiceResponse(resp, err, claim); err != nil {
+		return nil, err
+	}
+
+	if claim.Item == nil {
+		return nil, ErrNothingToClaim
+	}
+
+	return claim, nil
+}
+
+// ClaimSaleRewardIfAvailable claims the daily sale reward when there is one, it
+// returns nil and the status otherwise.
+func (session *Session) ClaimSaleRewardIfAvailable() (*SaleRewardClaim, *SaleRewardStatus, error) {
+	status, err := session.GetSaleRewardStatus()
+	if err != nil || !status.CanClaim {
+		return nil, status, err
+	}
+
+	claim, err := session.ClaimSaleReward()
+	return claim, status, err
+}
@@ -0,0 +1,165 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidKeyValues = errors.New("invalid KeyValues text")
+
+// keyValues is a node of Valve's KeyValues text format (items_game.txt), either a
+// string Value or Children, keys may repeat.
+type keyValues struct {
+	Key      string
+	Value    string
+	Children []*keyValues
+}
+
+// Child returns the first child named @key (case insensitive, like the game), nil
+// if there is none.
+func (kv *keyValues) Child(key string) *keyValues {
+	for _, child := range kv.Children {
+		if strings.EqualFold(child.Key, key) {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// Each calls @f for the children of every child named @key, since sections such
+// as "items" may be split in several blocks.
+func (kv *keyValues) Each(key string, f func(*keyValues)) {
+	for _, child := range kv.Children {
+		if strings.EqualFold(child.Key, key) {
+			for _, grandchild := range child.Children {
+				f(grandchild)
+			}
+		}
+	}
+}
+
+// Get returns the string value of the child @key, empty if missing.
+func (kv *keyValues) Get(key string) string {
+	if child := kv.Child(key); child != nil {
+		return child.Value
+	}
+
+	return ""
+}
+
+type kvTokenizer struct {
+	text string
+	pos  int
+}
+
+// next returns the next token, @str tells a string (quoted or not) from a brace, it
+// returns false at the end of the text.  Comments and conditionals ([$WIN32])
+// are skipped.
+func (t *kvTokenizer) next() (token string, str bool, ok bool, err error) {
+	for t.pos < len(t.text) {
+		c := t.text[t.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			t.pos++
+		case strings.HasPrefix(t.text[t.pos:], "//"):
+			if end := strings.IndexByte(t.text[t.pos:], '\n'); end >= 0 {
+				t.pos += end + 1
+			} else {
+				t.pos = len(t.text)
+			}
+		case c == '[':
+			end := strings.IndexByte(t.text[t.pos:], ']')
+			if end < 0 {
+				return "", false, false, ErrInvalidKeyValues
+			}
+			t.pos += end + 1
+		case c == '{' || c == '}':
+			t.pos++
+			return string(c), false, true, nil
+		case c == '"':
+			return t.quoted()
+		default:
+			start := t.pos
+			for t.pos < len(t.text) && !strings.ContainsRune(" \t\r\n{}\"", rune(t.text[t.pos])) {
+				t.pos++
+			}
+			return t.text[start:t.pos], true, true, nil
+		}
+	}
+
+	return "", false, false, nil
+}
+
+func (t *kvTokenizer) quoted() (string, bool, bool, error) {
+	var token strings.Builder
+	for t.pos++; t.pos < len(t.text); t.pos++ {
+		c := t.text[t.pos]
+		switch c {
+		case '"':
+			t.pos++
+			return token.String(), true, true, nil
+		case '\\':
+			if t.pos++; t.pos == len(t.text) {
+				return "", false, false, ErrInvalidKeyValues
+			}
+
+			switch c = t.text[t.pos]; c {
+			case 'n':
+				c = '\n'
+			case 't':
+				c = '\t'
+			}
+		}
+		token.WriteByte(c)
+	}
+
+	return "", false, false, ErrInvalidKeyValues
+}
+
+// parseKeyValues parses @text into a root node holding its top level keys.
+func parseKeyValues(text string) (*keyValues, error) {
+	t := &kvTokenizer{text: text}
+	root := &keyValues{}
+	stack := []*keyValues{root}
+
+	for {
+		key, str, ok, err := t.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			if len(stack) != 1 {
+				return nil, ErrInvalidKeyValues
+			}
+			return root, nil
+		}
+
+		parent := stack[len(stack)-1]
+		if !str {
+			if key != "}" || len(stack) == 1 {
+				return nil, ErrInvalidKeyValues
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		value, str, ok, err := t.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok || (!str && value != "{") {
+			return nil, ErrInvalidKeyValues
+		}
+
+		node := &keyValues{Key: key}
+		parent.Children = append(parent.Children, node)
+		if str {
+			node.Value = value
+		} else {
+			stack = append(stack, node)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+// Package schema downloads the item schemas of TF2 and CS:GO to tell what an item
+// is beyond its market name.  TF2 has GetSchemaOverview/GetSchemaItems, CS:GO only
+// publishes its items_game.txt through GetSchemaURL.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/multicus/steam"
+)
+
+// The games Fetch supports.
+const (
+	TF2AppID  = 440
+	CSGOAppID = 730
+)
+
+const (
+	apiGetSchemaOverview = "https://api.steampowered.com/IEconItems_%d/GetSchemaOverview/v1/?"
+	apiGetSchemaItems    = "https://api.steampowered.com/IEconItems_%d/GetSchemaItems/v1/?"
+	apiGetSchemaURL      = "https://api.steampowered.com/IEconItems_%d/GetSchemaURL/v2/?"
+)
+
+var (
+	ErrCannotLoadSchema = errors.New("unable to load item schema at this time")
+	ErrUnsupportedApp   = errors.New("item schema is only available for TF2 and CS:GO")
+)
+
+type Item struct {
+	DefIndex      uint32 `json:"defindex"`
+	Name          string `json:"name"`
+	ItemName      string `json:"item_name"`
+	ItemClass     string `json:"item_class"`
+	TypeName      string `json:"item_type_name"`
+	Quality       int    `json:"item_quality"`
+	MinLevel      int    `json:"min_ilevel"`
+	MaxLevel      int    `json:"max_ilevel"`
+	ImageURL      string `json:"image_url"`
+	ImageURLLarge string `json:"image_url_large"`
+}
+
+type Schema struct {
+	AppID     uint32
+	Items     map[uint32]*Item
+	Qualities map[int]string // quality id to display name
+	Fetched   time.Time
+}
+
+// ItemInfo is what the schema (and the tags, for the rarity) tell about an item.
+type ItemInfo struct {
+	Item    *Item
+	Quality string
+	Rarity  string
+}
+
+type resultStatus struct {
+	Status int `json:"status"`
+}
+
+func getJSON(client *http.Client, url string, v interface{}) error {
+	resp, err := client.Get(url)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Fetch downloads the schema of @appID, @language is e.g. "english".  Only TF2AppID
+// and CSGOAppID are supported, ErrUnsupportedApp is returned for the other games.
+// items_game.txt isn't localized, so CS:GO item names are localization tokens
+// (e.g. "#SFUI_WPNHUD_DesertEagle") whatever @language.
+func Fetch(client *http.Client, apiKey string, appID uint32, language string) (*Schema, error) {
+	switch appID {
+	case TF2AppID:
+		return fetchOverview(client, apiKey, appID, language)
+	case CSGOAppID:
+		return fetchItemsGame(client, apiKey, appID)
+	}
+
+	return nil, ErrUnsupportedApp
+}
+
+func fetchOverview(client *http.Client, apiKey string, appID uint32, language string) (*Schema, error) {
+	type Overview struct {
+		resultStatus
+		Qualities    map[string]int    `json:"qualities"`
+		QualityNames map[string]string `json:"qualityNames"`
+	}
+
+	type Items struct {
+		resultStatus
+		Items []*Item `json:"items"`
+		Next  *uint32 `json:"next"`
+	}
+
+	params := url.Values{
+		"key":      {apiKey},
+		"language": {language},
+	}
+
+	var overview struct {
+		Inner *Overview `json:"result"`
+	}
+	if err := getJSON(client, fmt.Sprintf(apiGetSchemaOverview, appID)+params.Encode(), &overview); err != nil {
+		return nil, err
+	}
+
+	if overview.Inner == nil || overview.Inner.Status != 1 {
+		return nil, ErrCannotLoadSchema
+	}
+
+	schema := &Schema{
+		AppID:     appID,
+		Items:     make(map[uint32]*Item),
+		Qualities: make(map[int]string),
+		Fetched:   time.Now(),
+	}
+
+	for name, id := range overview.Inner.Qualities {
+		if displayName, ok := overview.Inner.QualityNames[name]; ok {
+			name = displayName
+		}
+		schema.Qualities[id] = name
+	}
+
+	for {
+		var items struct {
+			Inner *Items `json:"result"`
+		}
+		if err := getJSON(client, fmt.Sprintf(apiGetSchemaItems, appID)+params.Encode(), &items); err != nil {
+			return nil, err
+		}
+
+		if items.Inner == nil || items.Inner.Status != 1 {
+			return nil, ErrCannotLoadSchema
+		}
+
+		for _, item := range items.Inner.Items {
+			schema.Items[item.DefIndex] = item
+		}
+
+		if items.Inner.Next == nil {
+			break
+		}
+
+		params.Set("start", strconv.FormatUint(uint64(*items.Inner.Next), 10))
+	}
+
+	return schema, nil
+}
+
+func fetchItemsGame(client *http.Client, apiKey string, appID uint32) (*Schema, error) {
+	type SchemaURL struct {
+		resultStatus
+		ItemsGameURL string `json:"items_game_url"`
+	}
+
+	var schemaURL struct {
+		Inner *SchemaURL `json:"result"`
+	}
+	if err := getJSON(client, fmt.Sprintf(apiGetSchemaURL, appID)+url.Values{"key": {apiKey}}.Encode(), &schemaURL); err != nil {
+		return nil, err
+	}
+
+	if schemaURL.Inner == nil || schemaURL.Inner.Status != 1 || len(schemaURL.Inner.ItemsGameURL) == 0 {
+		return nil, ErrCannotLoadSchema
+	}
+
+	resp, err := client.Get(schemaURL.Inner.ItemsGameURL)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	text, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := parseItemsGame(string(text))
+	if err != nil {
+		return nil, err
+	}
+
+	schema.AppID = appID
+	return schema, nil
+}
+
+// maxPrefabDepth bounds the prefab chains followed, in case they loop.
+const maxPrefabDepth = 8
+
+// itemsGameField returns the field @key of @item or, if it doesn't have it, of its
+// prefabs (space separated, the first one having it wins).
+func itemsGameField(prefabs map[string]*keyValues, item *keyValues, key string, depth int) string {
+	if value := item.Get(key); len(value) != 0 || depth == maxPrefabDepth {
+		return value
+	}
+
+	for _, name := range strings.Fields(item.Get("prefab")) {
+		if prefab, ok := prefabs[name]; ok {
+			if value := itemsGameField(prefabs, prefab, key, depth+1); len(value) != 0 {
+				return value
+			}
+		}
+	}
+
+	return ""
+}
+
+// parseItemsGame reads the items and qualities of an items_game.txt.
+func parseItemsGame(text string) (*Schema, error) {
+	root, err := parseKeyValues(text)
+	if err != nil {
+		return nil, err
+	}
+
+	game := root.Child("items_game")
+	if game == nil {
+		return nil, ErrCannotLoadSchema
+	}
+
+	schema := &Schema{
+		Items:     make(map[uint32]*Item),
+		Qualities: make(map[int]string),
+		Fetched:   time.Now(),
+	}
+
+	qualities := make(map[string]int)
+	game.Each("qualities", func(quality *keyValues) {
+		if id, err := strconv.Atoi(quality.Get("value")); err == nil {
+			qualities[quality.Key] = id
+			schema.Qualities[id] = quality.Key
+		}
+	})
+
+	prefabs := make(map[string]*keyValues)
+	game.Each("prefabs", func(prefab *keyValues) {
+		prefabs[prefab.Key] = prefab
+	})
+
+	game.Each("items", func(node *keyValues) {
+		defIndex, err := strconv.ParseUint(node.Key, 10, 32)
+		if err != nil {
+			// "default" holds the fields every item inherits.
+			return
+		}
+
+		field := func(key string) string {
+			return itemsGameField(prefabs, node, key, 0)
+		}
+
+		item := &Item{
+			DefIndex:  uint32(defIndex),
+			Name:      node.Get("name"),
+			ItemName:  field("item_name"),
+			ItemClass: field("item_class"),
+			TypeName:  field("item_type_name"),
+			Quality:   qualities[field("item_quality")],
+		}
+		item.MinLevel, _ = strconv.Atoi(field("min_ilevel"))
+		item.MaxLevel, _ = strconv.Atoi(field("max_ilevel"))
+
+		schema.Items[item.DefIndex] = item
+	})
+
+	return schema, nil
+}
+
+func (schema *Schema) Item(defIndex uint32) *Item {
+	return schema.Items[defIndex]
+}
+
+// Lookup finds @desc in the schema, this needs the app data which Steam only
+// sets for some games, items without it are only given their rarity.
+func (schema *Schema) Lookup(desc *steam.EconItemDesc) *ItemInfo {
+	info := &ItemInfo{}
+	for _, tag := range desc.Tags {
+		if tag.Category == "Rarity" {
+			info.Rarity = tag.Name
+		}
+	}
+
+	if desc.AppData == nil {
+		return info
+	}
+
+	if defIndex, err := strconv.ParseUint(desc.AppData.DefIndex, 10, 32); err == nil {
+		info.Item = schema.Item(uint32(defIndex))
+	}
+
+	if quality, err := strconv.Atoi(desc.AppData.Quality); err == nil {
+		info.Quality = schema.Qualities[quality]
+	} else if info.Item != nil {
+		info.Quality = schema.Qualities[info.Item.Quality]
+	}
+
+	return info
+}
+
+// Cache keeps the schemas for TTL, they are big and rarely change.
+type Cache struct {
+	Client   *http.Client
+	APIKey   string
+	Language string
+	TTL      time.Duration
+
+	schemas map[uint32]*Schema
+	mutex   sync.Mutex
+}
+
+func NewCache(client *http.Client, apiKey string, ttl time.Duration) *Cache {
+	return &Cache{
+		Client:   client,
+		APIKey:   apiKey,
+		Language: "english",
+		TTL:      ttl,
+		schemas:  make(map[uint32]*Schema),
+	}
+}
+
+// Get returns the cached schema of @appID, fetching it if it's missing or expired.
+func (cache *Cache) Get(appID uint32) (*Schema, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if schema, ok := cache.schemas[appID]; ok && time.Since(schema.Fetched) < cache.TTL {
+		return schema, nil
+	}
+
+	schema, err := Fetch(cache.Client, cache.APIKey, appID, cache.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.schemas[appID] = schema
+	return schema, nil
+}
+
+// Lookup is Schema.Lookup with the schema of @appID.
+func (cache *Cache) Lookup(appID uint32, desc *steam.EconItemDesc) (*ItemInfo, error) {
+	schema, err := cache.Get(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.Lookup(desc), nil
+}
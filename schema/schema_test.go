@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseKeyValues(t *testing.T) {
+	root, err := parseKeyValues(`
+		// comment
+		"a" { "b" "one \"quoted\" \\ value" c two [$WIN32]
+			"d" { } }
+		"a" { "e" "3" }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := root.Child("A")
+	if a == nil || a.Get("b") != `one "quoted" \ value` || a.Get("c") != "two" || a.Child("d") == nil {
+		t.Fatalf("a = %+v", a)
+	}
+
+	var keys []string
+	root.Each("a", func(kv *keyValues) {
+		keys = append(keys, kv.Key)
+	})
+	if strings.Join(keys, ",") != "b,c,d,e" {
+		t.Errorf("Each keys = %v", keys)
+	}
+
+	for _, text := range []string{`"a" {`, `"a" }`, `}`, `"a"`, `"a" "b`, `"a" { "b" }`} {
+		if _, err := parseKeyValues(text); err != ErrInvalidKeyValues {
+			t.Errorf("parseKeyValues(%q) err = %v, want ErrInvalidKeyValues", text, err)
+		}
+	}
+}
+
+// itemsGameTransport serves GetSchemaURL and the items_game.txt of testdata.
+type itemsGameTransport struct{}
+
+func (itemsGameTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := `{"result":{"status":1,"items_game_url":"http://media.steampowered.com/apps/730/scripts/items/items_game.txt"}}`
+	if strings.HasSuffix(req.URL.Path, ".txt") {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "items_game.txt"))
+		if err != nil {
+			return nil, err
+		}
+		body = string(data)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestFetchItemsGame(t *testing.T) {
+	schema, err := Fetch(&http.Client{Transport: itemsGameTransport{}}, "key", CSGOAppID, "english")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema.AppID != CSGOAppID || len(schema.Items) != 2 {
+		t.Fatalf("schema = %+v", schema)
+	}
+
+	deagle := schema.Item(1)
+	want := Item{
+		DefIndex:  1,
+		Name:      "weapon_deagle",
+		ItemName:  "#SFUI_WPNHUD_DesertEagle",
+		ItemClass: "weapon_deagle",
+		TypeName:  "#CSGO_Type_Pistol",
+		Quality:   4,
+		MinLevel:  1,
+		MaxLevel:  1,
+	}
+	if deagle == nil || *deagle != want {
+		t.Errorf("deagle = %+v, want %+v", deagle, want)
+	}
+
+	if kit := schema.Item(1314); kit == nil || kit.Quality != 1 || schema.Qualities[kit.Quality] != "genuine" {
+		t.Errorf("music kit = %+v", kit)
+	}
+}
+
+func TestFetchUnsupportedApp(t *testing.T) {
+	if _, err := Fetch(http.DefaultClient, "key", 570, "english"); err != ErrUnsupportedApp {
+		t.Errorf("err = %v, want ErrUnsupportedApp", err)
+	}
+}
@@ -0,0 +1,42 @@
+package steam
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// scriptVariable finds "var @name = <json>;" in the inline scripts of the page
+// and decodes the value into @v.  The value is read by the JSON decoder rather
+// than matched, so it doesn't matter what follows it or where it's in the page,
+// declarations whose value isn't JSON are skipped.
+func scriptVariable(page io.Reader, name string, v interface{}) (bool, error) {
+	doc, err := goquery.NewDocumentFromReader(page)
+	if err != nil {
+		return false, err
+	}
+
+	declaration := regexp.MustCompile(`\bvar\s+` + regexp.QuoteMeta(name) + `\s*=`)
+
+	var value json.RawMessage
+	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		script := s.Text()
+		for _, match := range declaration.FindAllStringIndex(script, -1) {
+			if json.NewDecoder(strings.NewReader(script[match[1]:])).Decode(&value) == nil {
+				return false
+			}
+			value = nil
+		}
+
+		return true
+	})
+
+	if value == nil {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(value, v)
+}
@@ -0,0 +1,127 @@
+package steam
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+// fixtureTransport answers every request with the file @name of testdata.
+type fixtureTransport struct {
+	name string
+}
+
+func (transport *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadFile(filepath.Join("testdata", transport.name))
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": {"text/html; charset=UTF-8"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func fixtureSession(name string) *Session {
+	return NewSession(&http.Client{Transport: &fixtureTransport{name}}, "")
+}
+
+func TestScriptVariable(t *testing.T) {
+	tests := []struct {
+		page  string
+		found bool
+		value int
+	}{
+		{`<script>var x = 1;</script>`, true, 1},
+		{`<script>var  x	=2</script>`, true, 2},
+		{`<script>var x = 3; var y = 4;</script>`, true, 3},
+		{`<script>var xy = 5; var x = 6;</script>`, true, 6},
+		{`<script>var x;</script>`, false, 0},
+		{`<script>var x = new Thing();</script><script>var x = 7;</script>`, true, 7},
+		{`<p>var x = 8;</p>`, false, 0},
+	}
+
+	for _, test := range tests {
+		var value int
+		found, err := scriptVariable(bytes.NewReader([]byte(test.page)), "x", &value)
+		if err != nil {
+			t.Errorf("scriptVariable(%q): %v", test.page, err)
+			continue
+		}
+
+		if found != test.found || value != test.value {
+			t.Errorf("scriptVariable(%q) = %v, %d, want %v, %d", test.page, found, value, test.found, test.value)
+		}
+	}
+}
+
+func TestGetInventoryAppStats(t *testing.T) {
+	stats, err := fixtureSession("inventory.html").GetInventoryAppStats(SteamID(76561197960287930))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("got %d apps, want 2", len(stats))
+	}
+
+	csgo := stats["730"]
+	if csgo.AppID != 730 || csgo.AssetCount != 42 || csgo.TradePermissions != "FULL" {
+		t.Errorf("unexpected app 730: %+v", csgo)
+	}
+
+	if ctx := csgo.Contexts["2"]; ctx == nil || ctx.ID != 2 || ctx.Name != "Backpack" {
+		t.Errorf("unexpected context 2 of app 730: %+v", ctx)
+	}
+
+	if len(stats["753"].Contexts) != 2 {
+		t.Errorf("got %d contexts for app 753, want 2", len(stats["753"].Contexts))
+	}
+}
+
+func TestGetInventoryAppStatsMissing(t *testing.T) {
+	_, err := fixtureSession("inventory_private.html").GetInventoryAppStats(SteamID(76561197960287930))
+	if err != ErrCannotFindAppContextData {
+		t.Fatalf("error = %v, want ErrCannotFindAppContextData", err)
+	}
+}
+
+func TestGetInventoryAppStatsEmpty(t *testing.T) {
+	// Steam has an empty array rather than an empty object for empty inventories.
+	stats, err := fixtureSession("inventory_empty.html").GetInventoryAppStats(SteamID(76561197960287930))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats) != 0 {
+		t.Fatalf("got %d apps for an empty inventory", len(stats))
+	}
+}
+
+func TestGetWalletInfo(t *testing.T) {
+	info, err := fixtureSession("market.html").GetWalletInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Currency != 1 || info.Country != "US" || info.Balance != 1234 || info.Success != 1 {
+		t.Errorf("unexpected wallet info: %+v", info)
+	}
+
+	if info.FeePercent != 0.05 || info.PublisherFeePercentDefault != 0.1 || info.FeeMinimum != 1 {
+		t.Errorf("unexpected fees: %+v", info)
+	}
+}
+
+func TestGetWalletInfoLoggedOut(t *testing.T) {
+	_, err := fixtureSession("market_logged_out.html").GetWalletInfo()
+	if err != ErrCannotFindWalletInfo {
+		t.Fatalf("error = %v, want ErrCannotFindWalletInfo", err)
+	}
+}
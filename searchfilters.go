@@ -0,0 +1,128 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+var ErrCannotLoadSearchFilters = errors.New("unable to load market search filters at this time")
+
+// MarketSearchTag is a value a search can be filtered on, Facet is the key of
+// the facet it belongs to (e.g. "730_Type").
+type MarketSearchTag struct {
+	Facet         string `json:"-"`
+	Name          string `json:"-"`
+	LocalizedName string `json:"localized_name"`
+	Matches       string `json:"matches"` // How many items have it, as shown
+}
+
+type MarketSearchFacet struct {
+	Key           string             `json:"-"`
+	AppID         uint32             `json:"appid"`
+	Name          string             `json:"name"`
+	LocalizedName string             `json:"localized_name"`
+	Tags          []*MarketSearchTag `json:"-"` // Sorted by name
+}
+
+// Tag returns the tag of the facet named @name, nil if there is none.
+func (facet *MarketSearchFacet) Tag(name string) *MarketSearchTag {
+	for _, tag := range facet.Tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+
+	return nil
+}
+
+// MarketSearchFilters are the facets of an app, by name (e.g. "Type", "Exterior").
+type MarketSearchFilters map[string]*MarketSearchFacet
+
+type MarketSearchOptions struct {
+	Query string
+	// SearchDescriptions also matches Query against the item descriptions.
+	SearchDescriptions bool
+	// Tags only keep the items having one of the tags of each facet.
+	Tags []*MarketSearchTag
+}
+
+func (options *MarketSearchOptions) values() url.Values {
+	values := url.Values{"query": {options.Query}}
+	if options.SearchDescriptions {
+		values.Set("search_descriptions", "1")
+	}
+
+	for _, tag := range options.Tags {
+		values.Add("category_"+tag.Facet+"[]", "tag_"+tag.Name)
+	}
+
+	return values
+}
+
+// GetMarketSearchFilters fetches the facets the search of @appID can be filtered on.
+func (session *Session) GetMarketSearchFilters(appID uint64) (MarketSearchFilters, error) {
+	resp, err := session.client.Get(fmt.Sprintf("https://steamcommunity.com/market/appfilters/%d?", appID) + url.Values{
+		"l": {session.language},
+	}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	type Facet struct {
+		MarketSearchFacet
+		Tags map[string]*MarketSearchTag `json:"tags"`
+	}
+
+	type Response struct {
+		Success bool              `json:"success"`
+		Facets  map[string]*Facet `json:"facets"`
+	}
+
+	var response Response
+	if err = session.decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, ErrCannotLoadSearchFilters
+	}
+
+	filters := MarketSearchFilters{}
+	for key, f := range response.Facets {
+		facet := &f.MarketSearchFacet
+		facet.Key = key
+		for name, tag := range f.Tags {
+			tag.Facet, tag.Name = key, name
+			facet.Tags = append(facet.Tags, tag)
+		}
+
+		sort.Slice(facet.Tags, func(i, j int) bool {
+			return facet.Tags[i].Name < facet.Tags[j].Name
+		})
+		filters[facet.Name] = facet
+	}
+
+	return filters, nil
+}
+
+// SearchMarket is GetMarketItemSearch with description search and tag filters.
+func (session *Session) SearchMarket(appID uint64, options *MarketSearchOptions, offset, count int) (*MarketItemSearchResponse, []*MarketSearchItem, error) {
+	values := options.values()
+	values.Set("appid", strconv.FormatUint(appID, 10))
+	values.Set("offset", strconv.Itoa(offset))
+	values.Set("count", strconv.Itoa(count))
+	values.Set("l", session.language)
+	return session.searchMarket(session.withCountry(values))
+}
@@ -0,0 +1,68 @@
+package steam
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+)
+
+var sessionStateURLs = []string{
+	"https://steamcommunity.com",
+	"https://store.steampowered.com",
+}
+
+// SessionState is what's needed to restore a logged in session without logging
+// in again, it can be marshalled to JSON.
+type SessionState struct {
+	OAuth     OAuth                     `json:"oauth"`
+	SessionID string                    `json:"sessionid"`
+	DeviceID  string                    `json:"deviceid"`
+	Cookies   map[string][]*http.Cookie `json:"cookies"` // by URL
+}
+
+// ExportState returns the state of the session, see RestoreState.
+func (session *Session) ExportState() *SessionState {
+	state := &SessionState{
+		OAuth:     session.oauth,
+		SessionID: session.sessionID,
+		DeviceID:  session.deviceID,
+		Cookies:   make(map[string][]*http.Cookie),
+	}
+
+	if session.client.Jar == nil {
+		return state
+	}
+
+	for _, rawURL := range sessionStateURLs {
+		u, _ := url.Parse(rawURL)
+		if cookies := session.client.Jar.Cookies(u); len(cookies) != 0 {
+			state.Cookies[rawURL] = cookies
+		}
+	}
+
+	return state
+}
+
+// RestoreState restores a state returned by ExportState, the cookies may have
+// expired since, see IsLoggedIn.
+func (session *Session) RestoreState(state *SessionState) error {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return err
+	}
+
+	for rawURL, cookies := range state.Cookies {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return err
+		}
+
+		jar.SetCookies(u, cookies)
+	}
+
+	session.client.Jar = jar
+	session.oauth = state.OAuth
+	session.sessionID = state.SessionID
+	session.deviceID = state.DeviceID
+	return nil
+}
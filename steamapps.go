@@ -1,27 +1,42 @@
 package steam
 
 import (
-	"encoding/json"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	apiUpToDateCheck = "https://api.steampowered.com/ISteamApps/UpToDateCheck/v1?"
+	apiGetAppList    = "https://api.steampowered.com/ISteamApps/GetAppList/v2/"
 )
 
+// AppListCacheTime is how long GetAppList re-uses the previously fetched list.
+var AppListCacheTime = 24 * time.Hour
+
+type App struct {
+	AppID uint32 `json:"appid"`
+	Name  string `json:"name"`
+}
+
+type AppList struct {
+	Apps    []*App
+	Fetched time.Time
+	names   map[uint32]string
+}
+
+type appListCache struct {
+	list  *AppList
+	mutex sync.Mutex
+}
+
 func (session *Session) GetRequiredSteamAppVersion(appID int) (int, error) {
 	resp, err := session.client.Get(apiUpToDateCheck + url.Values{
 		"appid":   {strconv.Itoa(appID)},
 		"version": {"0"},
 	}.Encode())
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return 0, err
-	}
 
 	type UpToDateCheckResponse struct {
 		RequiredVersion int `json:"required_version"`
@@ -32,8 +47,85 @@ func (session *Session) GetRequiredSteamAppVersion(appID int) (int, error) {
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return 0, err
 	}
 	return response.Inner.RequiredVersion, nil
 }
+
+func (session *Session) fetchAppList() (*AppList, error) {
+	resp, err := session.client.Get(apiGetAppList)
+
+	type Apps struct {
+		Apps []*App `json:"apps"`
+	}
+
+	type Response struct {
+		Inner Apps `json:"applist"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return nil, err
+	}
+
+	list := &AppList{
+		Apps:    response.Inner.Apps,
+		Fetched: time.Now(),
+		names:   make(map[uint32]string, len(response.Inner.Apps)),
+	}
+	for _, app := range list.Apps {
+		list.names[app.AppID] = app.Name
+	}
+
+	return list, nil
+}
+
+// GetAppList returns every app known to Steam, the list is large (and changes rarely)
+// so it is cached on the session for AppListCacheTime.
+func (session *Session) GetAppList() (*AppList, error) {
+	session.appList.mutex.Lock()
+	defer session.appList.mutex.Unlock()
+
+	if list := session.appList.list; list != nil && time.Since(list.Fetched) < AppListCacheTime {
+		return list, nil
+	}
+
+	list, err := session.fetchAppList()
+	if err != nil {
+		return nil, err
+	}
+
+	session.appList.list = list
+	return list, nil
+}
+
+// GetAppName resolves @appID using the cached app list.
+func (session *Session) GetAppName(appID uint32) (string, bool, error) {
+	list, err := session.GetAppList()
+	if err != nil {
+		return "", false, err
+	}
+
+	name, ok := list.Name(appID)
+	return name, ok, nil
+}
+
+func (list *AppList) Name(appID uint32) (string, bool) {
+	name, ok := list.names[appID]
+	return name, ok
+}
+
+// Search returns the apps whose name contain @query, case insensitive.
+func (list *AppList) Search(query string) []*App {
+	query = strings.ToLower(query)
+
+	apps := []*App{}
+	for _, app := range list.Apps {
+		if strings.Contains(strings.ToLower(app.Name), query) {
+			apps = append(apps, app)
+		}
+	}
+
+	return apps
+}
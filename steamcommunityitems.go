@@ -0,0 +1,89 @@
+package steam
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Steam Community items (trading cards, backgrounds, emoticons, gems) are in app
+// 753, context 6.
+const (
+	SteamCommunityAppID     = 753
+	SteamCommunityContextID = 6
+)
+
+// Item classes of the Steam Community items, as in the item_class tags.
+const (
+	CommunityItemCard        = 2
+	CommunityItemBackground  = 3
+	CommunityItemEmoticon    = 4
+	CommunityItemBoosterPack = 5
+	CommunityItemConsumable  = 6 // Gems and the like
+)
+
+// CommunityItemClassTag is the search tag for items of @itemClass (CommunityItem*).
+func CommunityItemClassTag(itemClass int) *MarketSearchTag {
+	return &MarketSearchTag{
+		Facet: "753_item_class",
+		Name:  "item_class_" + strconv.Itoa(itemClass),
+	}
+}
+
+// CommunityGameTag is the search tag for items of the game @appID.
+func CommunityGameTag(appID uint32) *MarketSearchTag {
+	return &MarketSearchTag{
+		Facet: "753_Game",
+		Name:  "app_" + strconv.FormatUint(uint64(appID), 10),
+	}
+}
+
+// CommunityFoilTag is the search tag for foil (or normal, if not @foil) cards.
+func CommunityFoilTag(foil bool) *MarketSearchTag {
+	name := "cardborder_0"
+	if foil {
+		name = "cardborder_1"
+	}
+
+	return &MarketSearchTag{Facet: "753_cardborder", Name: name}
+}
+
+// CommunityMarketHashName returns the market hash name of a Steam Community item
+// of @appID, which is prefixed by the app ID of the game, e.g. "620-GLaDOS" for a
+// Portal 2 trading card (foil cards end in " (Foil)", e.g. "620-GLaDOS (Foil)").
+func CommunityMarketHashName(appID uint32, name string) string {
+	return fmt.Sprintf("%d-%s", appID, name)
+}
+
+// SearchCommunityItems searches Steam Community items of @itemClass (CommunityItem*),
+// @gameAppID may be 0 for items of every game.
+func (session *Session) SearchCommunityItems(itemClass int, gameAppID uint32, query string, offset, count int) (*MarketItemSearchResponse, []*MarketSearchItem, error) {
+	options := &MarketSearchOptions{
+		Query: query,
+		Tags:  []*MarketSearchTag{CommunityItemClassTag(itemClass)},
+	}
+	if gameAppID != 0 {
+		options.Tags = append(options.Tags, CommunityGameTag(gameAppID))
+	}
+
+	return session.SearchMarket(SteamCommunityAppID, options, offset, count)
+}
+
+func (session *Session) SearchEmoticons(gameAppID uint32, query string, offset, count int) (*MarketItemSearchResponse, []*MarketSearchItem, error) {
+	return session.SearchCommunityItems(CommunityItemEmoticon, gameAppID, query, offset, count)
+}
+
+func (session *Session) SearchProfileBackgrounds(gameAppID uint32, query string, offset, count int) (*MarketItemSearchResponse, []*MarketSearchItem, error) {
+	return session.SearchCommunityItems(CommunityItemBackground, gameAppID, query, offset, count)
+}
+
+// SearchTradingCards @gameAppID may be 0 for the cards of every game.
+func (session *Session) SearchTradingCards(gameAppID uint32, foil bool, offset, count int) (*MarketItemSearchResponse, []*MarketSearchItem, error) {
+	options := &MarketSearchOptions{
+		Tags: []*MarketSearchTag{CommunityItemClassTag(CommunityItemCard), CommunityFoilTag(foil)},
+	}
+	if gameAppID != 0 {
+		options.Tags = append(options.Tags, CommunityGameTag(gameAppID))
+	}
+
+	return session.SearchMarket(SteamCommunityAppID, options, offset, count)
+}
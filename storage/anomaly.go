@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/multicus/steam"
+)
+
+// AnomalyAlert.Kind values.
+const (
+	AnomalyPriceSpike = "price_spike"
+	// AnomalyVolumeSpike is raised for a volume spike at a steady price too, the
+	// usual signal of wash trading.
+	AnomalyVolumeSpike     = "volume_spike"
+	AnomalySpreadInversion = "spread_inversion"
+)
+
+// AnomalyAlert Value is the latest price (in dollars, like the history), volume or
+// highest buy order (in cents), Baseline the median it's compared to or the lowest
+// sell order.
+type AnomalyAlert struct {
+	AppID          uint64
+	MarketHashName string
+	Kind           string
+	Time           time.Time
+	Value          float64
+	Baseline       float64
+}
+
+// AnomalyDetector compares the latest point of the stored price history of an
+// item to the median of the points in the Window before it, and checks the latest
+// order book for a buy order at or above the lowest sell order.
+type AnomalyDetector struct {
+	store Store
+
+	Window time.Duration
+	// MinPoints is how many points the window needs before anything is flagged.
+	MinPoints int
+	// PriceChange flags the price moving from the median by more than this fraction.
+	PriceChange float64
+	// VolumeFactor flags the volume being more than this many times the median.
+	VolumeFactor float64
+	// OnAlert is called for every alert, may be nil.
+	OnAlert func(*AnomalyAlert)
+	// Notifier is notified of every alert, may be nil.
+	Notifier steam.Notifier
+}
+
+func NewAnomalyDetector(store Store) *AnomalyDetector {
+	return &AnomalyDetector{
+		store:        store,
+		Window:       7 * 24 * time.Hour,
+		MinPoints:    24,
+		PriceChange:  0.5,
+		VolumeFactor: 5,
+	}
+}
+
+func medianOf(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+func (detector *AnomalyDetector) alert(alert *AnomalyAlert) error {
+	if detector.OnAlert != nil {
+		detector.OnAlert(alert)
+	}
+
+	if detector.Notifier == nil {
+		return nil
+	}
+
+	return detector.Notifier.Notify(&steam.Notification{
+		Source: steam.NotificationAnomaly,
+		Title:  alert.MarketHashName,
+		Text:   fmt.Sprintf("%s: %g (baseline %g)", alert.Kind, alert.Value, alert.Baseline),
+		Time:   alert.Time,
+		Data:   alert,
+	})
+}
+
+func (detector *AnomalyDetector) historyAlerts(appID uint64, marketHashName string, now time.Time) ([]*AnomalyAlert, error) {
+	prices, err := detector.store.PriceHistory(appID, marketHashName, now.Add(-detector.Window), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prices) < detector.MinPoints+1 || len(prices) < 2 {
+		return nil, nil
+	}
+
+	latest := prices[len(prices)-1]
+	latestTime, err := latest.Time()
+	if err != nil {
+		return nil, err
+	}
+
+	baseline := prices[:len(prices)-1]
+	values := make([]float64, len(baseline))
+	volumes := make([]float64, len(baseline))
+	for i, price := range baseline {
+		values[i] = price.Price
+		volumes[i] = float64(price.Volume())
+	}
+
+	alerts := []*AnomalyAlert{}
+	newAlert := func(kind string, value, base float64) {
+		alerts = append(alerts, &AnomalyAlert{
+			AppID:          appID,
+			MarketHashName: marketHashName,
+			Kind:           kind,
+			Time:           latestTime,
+			Value:          value,
+			Baseline:       base,
+		})
+	}
+
+	if medianPrice := medianOf(values); medianPrice > 0 && math.Abs(latest.Price/medianPrice-1) > detector.PriceChange {
+		newAlert(AnomalyPriceSpike, latest.Price, medianPrice)
+	}
+
+	if medianVolume := medianOf(volumes); medianVolume > 0 && float64(latest.Volume()) > medianVolume*detector.VolumeFactor {
+		newAlert(AnomalyVolumeSpike, float64(latest.Volume()), medianVolume)
+	}
+
+	return alerts, nil
+}
+
+func (detector *AnomalyDetector) orderBookAlert(appID uint64, marketHashName string, now time.Time) (*AnomalyAlert, error) {
+	books, err := detector.store.OrderBooks(appID, marketHashName, now.Add(-detector.Window), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(books) == 0 {
+		return nil, nil
+	}
+
+	book := books[len(books)-1]
+	if len(book.BuyOrders) == 0 || len(book.SellOrders) == 0 {
+		return nil, nil
+	}
+
+	highestBuy, lowestSell := book.BuyOrders[0].Price, book.SellOrders[0].Price
+	for _, level := range book.BuyOrders {
+		if level.Price > highestBuy {
+			highestBuy = level.Price
+		}
+	}
+
+	for _, level := range book.SellOrders {
+		if level.Price < lowestSell {
+			lowestSell = level.Price
+		}
+	}
+
+	if highestBuy < lowestSell {
+		return nil, nil
+	}
+
+	return &AnomalyAlert{
+		AppID:          appID,
+		MarketHashName: marketHashName,
+		Kind:           AnomalySpreadInversion,
+		Time:           book.Time,
+		Value:          float64(highestBuy),
+		Baseline:       float64(lowestSell),
+	}, nil
+}
+
+// Check looks for anomalies of an item as of @now and reports them to OnAlert and
+// Notifier, they are returned too.
+func (detector *AnomalyDetector) Check(appID uint64, marketHashName string, now time.Time) ([]*AnomalyAlert, error) {
+	alerts, err := detector.historyAlerts(appID, marketHashName, now)
+	if err != nil {
+		return nil, err
+	}
+
+	inversion, err := detector.orderBookAlert(appID, marketHashName, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if inversion != nil {
+		alerts = append(alerts, inversion)
+	}
+
+	for _, alert := range alerts {
+		if err = detector.alert(alert); err != nil {
+			return alerts, err
+		}
+	}
+
+	return alerts, nil
+}
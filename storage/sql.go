@@ -0,0 +1,387 @@
+package storage
+
+import (
+	"database/sql"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/multicus/steam"
+)
+
+const (
+	// DialectQuestion is for databases using ? placeholders (SQLite).
+	DialectQuestion = iota
+	// DialectDollar is for databases using $n placeholders (PostgreSQL).
+	DialectDollar
+)
+
+const (
+	orderSideBuy  = 0
+	orderSideSell = 1
+)
+
+// Times are stored as unix seconds so that the schema works everywhere.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS price_history (
+		appid BIGINT NOT NULL,
+		hash_name TEXT NOT NULL,
+		time BIGINT NOT NULL,
+		price DOUBLE PRECISION NOT NULL,
+		volume BIGINT NOT NULL,
+		PRIMARY KEY (appid, hash_name, time)
+	)`,
+	`CREATE TABLE IF NOT EXISTS order_books (
+		appid BIGINT NOT NULL,
+		hash_name TEXT NOT NULL,
+		time BIGINT NOT NULL,
+		side INTEGER NOT NULL,
+		price BIGINT NOT NULL,
+		quantity BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS order_books_item ON order_books (appid, hash_name, time)`,
+	`CREATE TABLE IF NOT EXISTS inventory_snapshots (
+		steamid BIGINT NOT NULL,
+		appid BIGINT NOT NULL,
+		contextid BIGINT NOT NULL,
+		time BIGINT NOT NULL,
+		PRIMARY KEY (steamid, appid, contextid, time)
+	)`,
+	`CREATE TABLE IF NOT EXISTS inventory_items (
+		steamid BIGINT NOT NULL,
+		appid BIGINT NOT NULL,
+		contextid BIGINT NOT NULL,
+		time BIGINT NOT NULL,
+		assetid BIGINT NOT NULL,
+		classid BIGINT NOT NULL,
+		instanceid BIGINT NOT NULL,
+		amount BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS inventory_items_snapshot ON inventory_items (steamid, appid, contextid, time)`,
+	`CREATE TABLE IF NOT EXISTS player_counts (
+		appid BIGINT NOT NULL,
+		time BIGINT NOT NULL,
+		players BIGINT NOT NULL,
+		PRIMARY KEY (appid, time)
+	)`,
+}
+
+// SQLStore implements Store on top of database/sql, no driver is imported,
+// that is up to the caller.  The schema is written for SQLite and PostgreSQL.
+// Note: IDs are stored in signed 64-bit columns, they are converted back as-is.
+type SQLStore struct {
+	db      *sql.DB
+	dialect int
+}
+
+func NewSQLStore(db *sql.DB, dialect int) *SQLStore {
+	return &SQLStore{db: db, dialect: dialect}
+}
+
+// CreateTables creates the tables (and indexes) that do not exist yet.
+func (store *SQLStore) CreateTables() error {
+	for _, query := range schema {
+		if _, err := store.db.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (store *SQLStore) Close() error {
+	return store.db.Close()
+}
+
+// rebind rewrites the ? placeholders of @query for the dialect.
+func (store *SQLStore) rebind(query string) string {
+	if store.dialect != DialectDollar {
+		return query
+	}
+
+	parts := strings.Split(query, "?")
+	rebound := parts[0]
+	for i, part := range parts[1:] {
+		rebound += "$" + strconv.Itoa(i+1) + part
+	}
+
+	return rebound
+}
+
+func unixBound(t time.Time, zero int64) int64 {
+	if t.IsZero() {
+		return zero
+	}
+
+	return t.Unix()
+}
+
+func (store *SQLStore) LastPriceTime(appID uint64, marketHashName string) (time.Time, error) {
+	var last sql.NullInt64
+	err := store.db.QueryRow(
+		store.rebind("SELECT MAX(time) FROM price_history WHERE appid = ? AND hash_name = ?"),
+		int64(appID), marketHashName,
+	).Scan(&last)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !last.Valid {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(last.Int64, 0).UTC(), nil
+}
+
+func (store *SQLStore) AddPrices(appID uint64, marketHashName string, prices []*steam.MarketItemPrice) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	deleteQuery := store.rebind("DELETE FROM price_history WHERE appid = ? AND hash_name = ? AND time = ?")
+	insertQuery := store.rebind("INSERT INTO price_history (appid, hash_name, time, price, volume) VALUES (?, ?, ?, ?, ?)")
+	for _, price := range prices {
+		t, err := price.Time()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if _, err = tx.Exec(deleteQuery, int64(appID), marketHashName, t.Unix()); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if _, err = tx.Exec(insertQuery, int64(appID), marketHashName, t.Unix(), price.Price, int64(price.Volume())); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (store *SQLStore) PriceHistory(appID uint64, marketHashName string, since, until time.Time) ([]*steam.MarketItemPrice, error) {
+	rows, err := store.db.Query(
+		store.rebind("SELECT time, price, volume FROM price_history WHERE appid = ? AND hash_name = ? AND time >= ? AND time <= ? ORDER BY time"),
+		int64(appID), marketHashName, unixBound(since, math.MinInt64), unixBound(until, math.MaxInt64),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := []*steam.MarketItemPrice{}
+	for rows.Next() {
+		var t, volume int64
+		price := &steam.MarketItemPrice{}
+		if err = rows.Scan(&t, &price.Price, &volume); err != nil {
+			return nil, err
+		}
+
+		// Same format as Steam, so that MarketItemPrice.Time() works.
+		price.Date = time.Unix(t, 0).UTC().Format("Jan 02 2006 15") + ": +0"
+		price.Count = strconv.FormatInt(volume, 10)
+		prices = append(prices, price)
+	}
+
+	return prices, rows.Err()
+}
+
+func (store *SQLStore) AddOrderBook(snapshot *OrderBookSnapshot) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := store.rebind("INSERT INTO order_books (appid, hash_name, time, side, price, quantity) VALUES (?, ?, ?, ?, ?, ?)")
+	insert := func(side int, levels []OrderBookLevel) error {
+		for _, level := range levels {
+			_, err := tx.Exec(query, int64(snapshot.AppID), snapshot.MarketHashName, snapshot.Time.Unix(), side, int64(level.Price), int64(level.Quantity))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err = insert(orderSideBuy, snapshot.BuyOrders); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = insert(orderSideSell, snapshot.SellOrders); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (store *SQLStore) OrderBooks(appID uint64, marketHashName string, since, until time.Time) ([]*OrderBookSnapshot, error) {
+	rows, err := store.db.Query(
+		store.rebind("SELECT time, side, price, quantity FROM order_books WHERE appid = ? AND hash_name = ? AND time >= ? AND time <= ? ORDER BY time, side, price"),
+		int64(appID), marketHashName, unixBound(since, math.MinInt64), unixBound(until, math.MaxInt64),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := []*OrderBookSnapshot{}
+	var snapshot *OrderBookSnapshot
+	for rows.Next() {
+		var t, price, quantity int64
+		var side int
+		if err = rows.Scan(&t, &side, &price, &quantity); err != nil {
+			return nil, err
+		}
+
+		if snapshot == nil || snapshot.Time.Unix() != t {
+			snapshot = &OrderBookSnapshot{
+				AppID:          appID,
+				MarketHashName: marketHashName,
+				Time:           time.Unix(t, 0).UTC(),
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+
+		level := OrderBookLevel{Price: uint64(price), Quantity: uint64(quantity)}
+		if side == orderSideBuy {
+			snapshot.BuyOrders = append(snapshot.BuyOrders, level)
+		} else {
+			snapshot.SellOrders = append(snapshot.SellOrders, level)
+		}
+	}
+
+	return snapshots, rows.Err()
+}
+
+func (store *SQLStore) AddInventory(snapshot *InventorySnapshot) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	key := []interface{}{int64(snapshot.SteamID), int64(snapshot.AppID), int64(snapshot.ContextID), snapshot.Time.Unix()}
+	if _, err = tx.Exec(store.rebind("INSERT INTO inventory_snapshots (steamid, appid, contextid, time) VALUES (?, ?, ?, ?)"), key...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := store.rebind("INSERT INTO inventory_items (steamid, appid, contextid, time, assetid, classid, instanceid, amount) VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
+	for _, item := range snapshot.Items {
+		args := append(key, int64(item.AssetID), int64(item.ClassID), int64(item.InstanceID), int64(item.Amount))
+		if _, err = tx.Exec(query, args...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (store *SQLStore) Inventories(sid steam.SteamID, appID, contextID uint64, since, until time.Time) ([]*InventorySnapshot, error) {
+	key := []interface{}{int64(sid), int64(appID), int64(contextID), unixBound(since, math.MinInt64), unixBound(until, math.MaxInt64)}
+	rows, err := store.db.Query(
+		store.rebind("SELECT time FROM inventory_snapshots WHERE steamid = ? AND appid = ? AND contextid = ? AND time >= ? AND time <= ? ORDER BY time"),
+		key...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []*InventorySnapshot{}
+	byTime := map[int64]*InventorySnapshot{}
+	for rows.Next() {
+		var t int64
+		if err = rows.Scan(&t); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		snapshot := &InventorySnapshot{
+			SteamID:   sid,
+			AppID:     appID,
+			ContextID: contextID,
+			Time:      time.Unix(t, 0).UTC(),
+			Items:     []steam.InventoryItem{},
+		}
+		snapshots = append(snapshots, snapshot)
+		byTime[t] = snapshot
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = store.db.Query(
+		store.rebind("SELECT time, assetid, classid, instanceid, amount FROM inventory_items WHERE steamid = ? AND appid = ? AND contextid = ? AND time >= ? AND time <= ?"),
+		key...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t, assetID, classID, instanceID, amount int64
+		if err = rows.Scan(&t, &assetID, &classID, &instanceID, &amount); err != nil {
+			return nil, err
+		}
+
+		snapshot, ok := byTime[t]
+		if !ok {
+			continue
+		}
+
+		snapshot.Items = append(snapshot.Items, steam.InventoryItem{
+			AppID:      uint32(appID),
+			ContextID:  contextID,
+			AssetID:    uint64(assetID),
+			ClassID:    uint64(classID),
+			InstanceID: uint64(instanceID),
+			Amount:     uint64(amount),
+		})
+	}
+
+	return snapshots, rows.Err()
+}
+
+func (store *SQLStore) AddPlayerCount(sample *steam.PlayerCountSample) error {
+	_, err := store.db.Exec(
+		store.rebind("INSERT INTO player_counts (appid, time, players) VALUES (?, ?, ?)"),
+		int64(sample.AppID), sample.Time.Unix(), int64(sample.Count),
+	)
+	return err
+}
+
+func (store *SQLStore) PlayerCounts(appID uint32, since, until time.Time) ([]*steam.PlayerCountSample, error) {
+	rows, err := store.db.Query(
+		store.rebind("SELECT time, players FROM player_counts WHERE appid = ? AND time >= ? AND time <= ? ORDER BY time"),
+		int64(appID), unixBound(since, math.MinInt64), unixBound(until, math.MaxInt64),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	samples := []*steam.PlayerCountSample{}
+	for rows.Next() {
+		var t, players int64
+		if err = rows.Scan(&t, &players); err != nil {
+			return nil, err
+		}
+
+		samples = append(samples, &steam.PlayerCountSample{
+			AppID: appID,
+			Count: uint32(players),
+			Time:  time.Unix(t, 0).UTC(),
+		})
+	}
+
+	return samples, rows.Err()
+}
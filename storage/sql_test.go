@@ -0,0 +1,486 @@
+package storage
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multicus/steam"
+)
+
+// fakeDriver is just enough of a database to run the queries of SQLStore: it
+// understands their INSERT, DELETE and SELECT shapes over in-memory tables and
+// refuses the placeholders of the other dialect, so that rebind is checked too.
+type fakeDriver struct {
+	dollar bool
+}
+
+type fakeRow map[string]driver.Value
+
+type fakeDB struct {
+	tables map[string][]fakeRow
+	mutex  sync.Mutex
+}
+
+var (
+	fakeDBs   = map[string]*fakeDB{}
+	fakeMutex sync.Mutex
+)
+
+func init() {
+	sql.Register("fake-question", &fakeDriver{})
+	sql.Register("fake-dollar", &fakeDriver{dollar: true})
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeMutex.Lock()
+	defer fakeMutex.Unlock()
+
+	db, ok := fakeDBs[name]
+	if !ok {
+		db = &fakeDB{tables: map[string][]fakeRow{}}
+		fakeDBs[name] = db
+	}
+
+	return &fakeConn{driver: d, db: db}, nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+	db     *fakeDB
+}
+
+func (conn *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: conn, query: query}, nil
+}
+
+func (conn *fakeConn) Close() error              { return nil }
+func (conn *fakeConn) Begin() (driver.Tx, error) { return conn, nil }
+func (conn *fakeConn) Commit() error             { return nil }
+func (conn *fakeConn) Rollback() error           { return nil }
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (stmt *fakeStmt) Close() error  { return nil }
+func (stmt *fakeStmt) NumInput() int { return -1 }
+
+var (
+	insertPattern    = regexp.MustCompile(`^INSERT INTO (\w+) \(([^)]*)\) VALUES \(([^)]*)\)$`)
+	deletePattern    = regexp.MustCompile(`^DELETE FROM (\w+) WHERE (.*)$`)
+	selectPattern    = regexp.MustCompile(`^SELECT (.*?) FROM (\w+) WHERE (.*?)(?: ORDER BY (.*))?$`)
+	conditionPattern = regexp.MustCompile(`^(\w+) (=|>=|<=) (\S+)$`)
+)
+
+// args resolves the placeholders of the query in order.
+type fakeArgs struct {
+	dollar bool
+	values []driver.Value
+	next   int
+}
+
+func (args *fakeArgs) get(placeholder string) (driver.Value, error) {
+	if !args.dollar {
+		if placeholder != "?" || args.next >= len(args.values) {
+			return nil, fmt.Errorf("bad placeholder %q", placeholder)
+		}
+		args.next++
+		return args.values[args.next-1], nil
+	}
+
+	if !strings.HasPrefix(placeholder, "$") {
+		return nil, fmt.Errorf("bad placeholder %q", placeholder)
+	}
+
+	n, err := strconv.Atoi(placeholder[1:])
+	if err != nil || n < 1 || n > len(args.values) {
+		return nil, fmt.Errorf("bad placeholder %q", placeholder)
+	}
+	return args.values[n-1], nil
+}
+
+func compare(a, b driver.Value) int {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			}
+			return 0
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	}
+
+	panic(fmt.Sprintf("cannot compare %T and %T", a, b))
+}
+
+// where returns the rows of @table matching @conditions.
+func (stmt *fakeStmt) where(table []fakeRow, conditions string, args *fakeArgs) ([]fakeRow, []fakeRow, error) {
+	type condition struct {
+		column, op string
+		value      driver.Value
+	}
+
+	var conds []condition
+	for _, cond := range strings.Split(conditions, " AND ") {
+		m := conditionPattern.FindStringSubmatch(cond)
+		if m == nil {
+			return nil, nil, fmt.Errorf("bad condition %q", cond)
+		}
+
+		value, err := args.get(m[3])
+		if err != nil {
+			return nil, nil, err
+		}
+		conds = append(conds, condition{m[1], m[2], value})
+	}
+
+	var matched, rest []fakeRow
+	for _, row := range table {
+		ok := true
+		for _, cond := range conds {
+			c := compare(row[cond.column], cond.value)
+			ok = ok && ((cond.op == "=" && c == 0) || (cond.op == ">=" && c >= 0) || (cond.op == "<=" && c <= 0))
+		}
+
+		if ok {
+			matched = append(matched, row)
+		} else {
+			rest = append(rest, row)
+		}
+	}
+
+	return matched, rest, nil
+}
+
+func (stmt *fakeStmt) Exec(values []driver.Value) (driver.Result, error) {
+	db := stmt.conn.db
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	args := &fakeArgs{dollar: stmt.conn.driver.dollar, values: values}
+	if strings.HasPrefix(stmt.query, "CREATE ") {
+		return driver.RowsAffected(0), nil
+	}
+
+	if m := insertPattern.FindStringSubmatch(stmt.query); m != nil {
+		columns, placeholders := strings.Split(m[2], ", "), strings.Split(m[3], ", ")
+		if len(columns) != len(placeholders) {
+			return nil, errors.New("column count mismatch")
+		}
+
+		row := fakeRow{}
+		for i, column := range columns {
+			value, err := args.get(placeholders[i])
+			if err != nil {
+				return nil, err
+			}
+			row[column] = value
+		}
+
+		db.tables[m[1]] = append(db.tables[m[1]], row)
+		return driver.RowsAffected(1), nil
+	}
+
+	if m := deletePattern.FindStringSubmatch(stmt.query); m != nil {
+		matched, rest, err := stmt.where(db.tables[m[1]], m[2], args)
+		if err != nil {
+			return nil, err
+		}
+
+		db.tables[m[1]] = rest
+		return driver.RowsAffected(len(matched)), nil
+	}
+
+	return nil, fmt.Errorf("unsupported exec %q", stmt.query)
+}
+
+func (stmt *fakeStmt) Query(values []driver.Value) (driver.Rows, error) {
+	db := stmt.conn.db
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	m := selectPattern.FindStringSubmatch(stmt.query)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported query %q", stmt.query)
+	}
+
+	args := &fakeArgs{dollar: stmt.conn.driver.dollar, values: values}
+	matched, _, err := stmt.where(db.tables[m[2]], m[3], args)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := strings.Split(m[1], ", ")
+	if len(columns) == 1 && strings.HasPrefix(columns[0], "MAX(") {
+		column := strings.TrimSuffix(strings.TrimPrefix(columns[0], "MAX("), ")")
+		var max driver.Value
+		for _, row := range matched {
+			if max == nil || compare(row[column], max) > 0 {
+				max = row[column]
+			}
+		}
+		return &fakeRows{columns: columns, rows: [][]driver.Value{{max}}}, nil
+	}
+
+	if len(m[4]) != 0 {
+		order := strings.Split(m[4], ", ")
+		sort.SliceStable(matched, func(i, j int) bool {
+			for _, column := range order {
+				if c := compare(matched[i][column], matched[j][column]); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+
+	rows := &fakeRows{columns: columns}
+	for _, row := range matched {
+		values := make([]driver.Value, len(columns))
+		for i, column := range columns {
+			values[i] = row[column]
+		}
+		rows.rows = append(rows.rows, values)
+	}
+
+	return rows, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (rows *fakeRows) Columns() []string { return rows.columns }
+func (rows *fakeRows) Close() error      { return nil }
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+	return nil
+}
+
+var dialects = []struct {
+	name    string
+	driver  string
+	dialect int
+}{
+	{"question", "fake-question", DialectQuestion},
+	{"dollar", "fake-dollar", DialectDollar},
+}
+
+// eachDialect runs @test with a fresh store for every dialect.
+func eachDialect(t *testing.T, test func(t *testing.T, store *SQLStore)) {
+	for _, d := range dialects {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			db, err := sql.Open(d.driver, t.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			store := NewSQLStore(db, d.dialect)
+			defer store.Close()
+
+			if err = store.CreateTables(); err != nil {
+				t.Fatal(err)
+			}
+
+			test(t, store)
+		})
+	}
+}
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		dialect int
+		query   string
+		want    string
+	}{
+		{DialectQuestion, "SELECT a FROM t WHERE b = ? AND c = ?", "SELECT a FROM t WHERE b = ? AND c = ?"},
+		{DialectDollar, "SELECT a FROM t WHERE b = ? AND c = ?", "SELECT a FROM t WHERE b = $1 AND c = $2"},
+		{DialectDollar, "INSERT INTO t (a, b, c) VALUES (?, ?, ?)", "INSERT INTO t (a, b, c) VALUES ($1, $2, $3)"},
+		{DialectDollar, "DELETE FROM t", "DELETE FROM t"},
+		{DialectDollar, "?", "$1"},
+	}
+
+	for _, test := range tests {
+		store := &SQLStore{dialect: test.dialect}
+		if got := store.rebind(test.query); got != test.want {
+			t.Errorf("rebind(%d, %q) = %q, want %q", test.dialect, test.query, got, test.want)
+		}
+	}
+}
+
+func TestSQLStorePriceHistory(t *testing.T) {
+	eachDialect(t, func(t *testing.T, store *SQLStore) {
+		const name = "AK-47 | Redline (Field-Tested)"
+
+		last, err := store.LastPriceTime(730, name)
+		if err != nil || !last.IsZero() {
+			t.Fatalf("LastPriceTime of empty store = %v, %v", last, err)
+		}
+
+		err = store.AddPrices(730, name, []*steam.MarketItemPrice{
+			{Date: "Jan 02 2020 01: +0", Price: 12.5, Count: "35"},
+			{Date: "Jan 01 2020 01: +0", Price: 12.3, Count: "40"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Adding a price again replaces it.
+		if err = store.AddPrices(730, name, []*steam.MarketItemPrice{{Date: "Jan 02 2020 01: +0", Price: 13, Count: "30"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = store.AddPrices(440, name, []*steam.MarketItemPrice{{Date: "Jan 03 2020 01: +0", Price: 1, Count: "1"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		last, err = store.LastPriceTime(730, name)
+		if want := time.Date(2020, 1, 2, 1, 0, 0, 0, time.UTC); err != nil || !last.Equal(want) {
+			t.Errorf("LastPriceTime = %v, %v, want %v", last, err, want)
+		}
+
+		prices, err := store.PriceHistory(730, name, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*steam.MarketItemPrice{
+			{Date: "Jan 01 2020 01: +0", Price: 12.3, Count: "40"},
+			{Date: "Jan 02 2020 01: +0", Price: 13, Count: "30"},
+		}
+		if !reflect.DeepEqual(prices, want) {
+			t.Errorf("PriceHistory = %+v, want %+v", prices, want)
+		}
+
+		prices, err = store.PriceHistory(730, name, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), time.Time{})
+		if err != nil || len(prices) != 1 || prices[0].Price != 13 {
+			t.Errorf("PriceHistory since Jan 02 = %+v, %v", prices, err)
+		}
+	})
+}
+
+func TestSQLStoreOrderBooks(t *testing.T) {
+	eachDialect(t, func(t *testing.T, store *SQLStore) {
+		first := &OrderBookSnapshot{
+			AppID:          730,
+			MarketHashName: "Case",
+			Time:           time.Unix(1000, 0).UTC(),
+			BuyOrders:      []OrderBookLevel{{Price: 10, Quantity: 5}, {Price: 11, Quantity: 2}},
+			SellOrders:     []OrderBookLevel{{Price: 12, Quantity: 3}},
+		}
+		second := &OrderBookSnapshot{
+			AppID:          730,
+			MarketHashName: "Case",
+			Time:           time.Unix(2000, 0).UTC(),
+			SellOrders:     []OrderBookLevel{{Price: 13, Quantity: 1}},
+		}
+
+		for _, snapshot := range []*OrderBookSnapshot{second, first} {
+			if err := store.AddOrderBook(snapshot); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		snapshots, err := store.OrderBooks(730, "Case", time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(snapshots, []*OrderBookSnapshot{first, second}) {
+			t.Errorf("OrderBooks = %+v", snapshots)
+		}
+
+		snapshots, err = store.OrderBooks(730, "Case", time.Time{}, time.Unix(1500, 0))
+		if err != nil || len(snapshots) != 1 || !reflect.DeepEqual(snapshots[0], first) {
+			t.Errorf("OrderBooks until 1500 = %+v, %v", snapshots, err)
+		}
+	})
+}
+
+func TestSQLStoreInventories(t *testing.T) {
+	eachDialect(t, func(t *testing.T, store *SQLStore) {
+		sid := steam.SteamID(76561197960287930)
+		snapshot := &InventorySnapshot{
+			SteamID:   sid,
+			AppID:     730,
+			ContextID: 2,
+			Time:      time.Unix(1000, 0).UTC(),
+			Items: []steam.InventoryItem{
+				{AppID: 730, ContextID: 2, AssetID: 1, ClassID: 10, InstanceID: 0, Amount: 1},
+				{AppID: 730, ContextID: 2, AssetID: 2, ClassID: 20, InstanceID: 5, Amount: 3},
+			},
+		}
+		empty := &InventorySnapshot{
+			SteamID:   sid,
+			AppID:     730,
+			ContextID: 2,
+			Time:      time.Unix(2000, 0).UTC(),
+			Items:     []steam.InventoryItem{},
+		}
+
+		for _, s := range []*InventorySnapshot{snapshot, empty} {
+			if err := store.AddInventory(s); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		snapshots, err := store.Inventories(sid, 730, 2, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(snapshots, []*InventorySnapshot{snapshot, empty}) {
+			t.Errorf("Inventories = %+v", snapshots)
+		}
+
+		if snapshots, err = store.Inventories(sid, 440, 2, time.Time{}, time.Time{}); err != nil || len(snapshots) != 0 {
+			t.Errorf("Inventories of another app = %+v, %v", snapshots, err)
+		}
+	})
+}
+
+func TestSQLStorePlayerCounts(t *testing.T) {
+	eachDialect(t, func(t *testing.T, store *SQLStore) {
+		for _, count := range []uint32{300, 100} {
+			sample := &steam.PlayerCountSample{AppID: 730, Count: count, Time: time.Unix(int64(count), 0).UTC()}
+			if err := store.AddPlayerCount(sample); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		samples, err := store.PlayerCounts(730, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(samples) != 2 || samples[0].Count != 100 || samples[1].Count != 300 || samples[1].AppID != 730 {
+			t.Errorf("PlayerCounts = %+v", samples)
+		}
+	})
+}
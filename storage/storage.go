@@ -0,0 +1,51 @@
+// Package storage persists price histories, order book and inventory snapshots
+// and player counts for later analysis, see SQLStore for a database/sql implementation.
+package storage
+
+import (
+	"time"
+
+	"github.com/multicus/steam"
+)
+
+type OrderBookLevel struct {
+	Price    uint64 // in cents
+	Quantity uint64
+}
+
+// OrderBookSnapshot is the state of the buy and sell orders of an item at Time.
+type OrderBookSnapshot struct {
+	AppID          uint64
+	MarketHashName string
+	Time           time.Time
+	BuyOrders      []OrderBookLevel
+	SellOrders     []OrderBookLevel
+}
+
+type InventorySnapshot struct {
+	SteamID   steam.SteamID
+	AppID     uint64
+	ContextID uint64
+	Time      time.Time
+	Items     []steam.InventoryItem
+}
+
+// Store can be passed wherever a steam.PriceHistoryStore or a
+// steam.PlayerCountStore is expected.
+// Ranges are inclusive and a zero time means no bound.
+type Store interface {
+	steam.PriceHistoryStore
+	steam.PlayerCountStore
+
+	PriceHistory(appID uint64, marketHashName string, since, until time.Time) ([]*steam.MarketItemPrice, error)
+
+	AddOrderBook(snapshot *OrderBookSnapshot) error
+	OrderBooks(appID uint64, marketHashName string, since, until time.Time) ([]*OrderBookSnapshot, error)
+
+	AddInventory(snapshot *InventorySnapshot) error
+	Inventories(sid steam.SteamID, appID, contextID uint64, since, until time.Time) ([]*InventorySnapshot, error)
+
+	PlayerCounts(appID uint32, since, until time.Time) ([]*steam.PlayerCountSample, error)
+
+	Close() error
+}
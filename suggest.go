@@ -0,0 +1,215 @@
+package steam
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var ErrNoPriceData = errors.New("not enough market data to suggest a price")
+
+// How far back SuggestPrice looks at the sales.
+const suggestRecentSales = 7 * 24 * time.Hour
+
+// MarketPriceData is what price strategies decide on, all prices are in cents
+// of the wallet currency and include the fees (what the buyer pays).
+type MarketPriceData struct {
+	Histogram *MarketOrderHistogram
+	Overview  *MarketItemPriceOverview
+	// RecentSales are the hourly (or daily, for older ones) median sale prices.
+	RecentSales []uint64
+	// RecentHistory is where RecentSales come from, with the volumes.
+	RecentHistory []*MarketItemPrice
+}
+
+// MedianSale is the median of RecentSales, or the overview median without any.
+func (data *MarketPriceData) MedianSale() uint64 {
+	if len(data.RecentSales) == 0 {
+		if data.Overview != nil {
+			return data.Overview.ParsedMedian
+		}
+		return 0
+	}
+
+	return data.SalePercentile(0.5)
+}
+
+// SalePercentile @p between 0 and 1, 0 without any recent sale.
+func (data *MarketPriceData) SalePercentile(p float64) uint64 {
+	if len(data.RecentSales) == 0 {
+		return 0
+	}
+
+	sorted := make([]uint64, len(data.RecentSales))
+	copy(sorted, data.RecentSales)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	i := int(math.Round(p * float64(len(sorted)-1)))
+	if i < 0 {
+		i = 0
+	} else if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+
+	return sorted[i]
+}
+
+// LowestSell falls back to the overview lowest price when there is no histogram.
+func (data *MarketPriceData) LowestSell() uint64 {
+	if data.Histogram != nil && data.Histogram.LowestSellOrder != 0 {
+		return data.Histogram.LowestSellOrder
+	}
+
+	if data.Overview != nil {
+		return data.Overview.ParsedLowest
+	}
+
+	return 0
+}
+
+func (data *MarketPriceData) HighestBuy() uint64 {
+	if data.Histogram != nil {
+		return data.Histogram.HighestBuyOrder
+	}
+
+	return 0
+}
+
+// PriceSuggestion List is what to list at and Buy what to place a buy order at,
+// either may be 0 when the strategy has nothing to suggest.
+type PriceSuggestion struct {
+	List uint64
+	Buy  uint64
+	Data *MarketPriceData
+}
+
+type PriceStrategy interface {
+	Suggest(data *MarketPriceData) (*PriceSuggestion, error)
+}
+
+// UndercutStrategy lists @Step below the lowest sell order and places buy orders
+// @Step above the highest buy order, never crossing the other side.
+type UndercutStrategy struct {
+	Step uint64
+}
+
+func (strategy *UndercutStrategy) Suggest(data *MarketPriceData) (*PriceSuggestion, error) {
+	step := strategy.Step
+	if step == 0 {
+		step = 1
+	}
+
+	lowest, highest := data.LowestSell(), data.HighestBuy()
+	if lowest == 0 && highest == 0 {
+		return nil, ErrNoPriceData
+	}
+
+	suggestion := &PriceSuggestion{Data: data}
+	if lowest > step {
+		suggestion.List = lowest - step
+		if suggestion.List <= highest {
+			suggestion.List = lowest
+		}
+	}
+
+	if highest != 0 {
+		suggestion.Buy = highest + step
+		if lowest != 0 && suggestion.Buy >= lowest {
+			suggestion.Buy = highest
+		}
+	}
+
+	return suggestion, nil
+}
+
+// MatchStrategy lists at the lowest sell order and buys at the highest buy order,
+// falling back to the median sale price when a side is empty.
+type MatchStrategy struct{}
+
+func (strategy *MatchStrategy) Suggest(data *MarketPriceData) (*PriceSuggestion, error) {
+	suggestion := &PriceSuggestion{
+		List: data.LowestSell(),
+		Buy:  data.HighestBuy(),
+		Data: data,
+	}
+
+	median := data.MedianSale()
+	if suggestion.List == 0 {
+		suggestion.List = median
+	}
+	if suggestion.Buy == 0 {
+		suggestion.Buy = median
+	}
+
+	if suggestion.List == 0 && suggestion.Buy == 0 {
+		return nil, ErrNoPriceData
+	}
+
+	return suggestion, nil
+}
+
+// PercentileStrategy lists at the @Percentile of the recent sales (e.g. 0.75)
+// and buys at 1 - @Percentile of them.
+type PercentileStrategy struct {
+	Percentile float64
+}
+
+func (strategy *PercentileStrategy) Suggest(data *MarketPriceData) (*PriceSuggestion, error) {
+	if len(data.RecentSales) == 0 {
+		return nil, ErrNoPriceData
+	}
+
+	return &PriceSuggestion{
+		List: data.SalePercentile(strategy.Percentile),
+		Buy:  data.SalePercentile(1 - strategy.Percentile),
+		Data: data,
+	}, nil
+}
+
+// GetMarketPriceData gathers the histogram, overview and a week of sales of the
+// item, in the wallet currency because that's what the price history is in.
+func (session *Session) GetMarketPriceData(appID uint64, marketHashName string) (*MarketPriceData, error) {
+	info, err := session.GetWalletInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	currencyID := strconv.FormatUint(uint64(info.Currency), 10)
+	data := &MarketPriceData{}
+	if data.Overview, err = session.GetMarketItemPriceOverview(appID, "", currencyID, marketHashName); err != nil {
+		return nil, err
+	}
+
+	itemNameID, err := session.GetMarketItemNameID(appID, marketHashName)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Histogram, err = session.GetMarketOrderHistogram(itemNameID, "", currencyID); err != nil {
+		return nil, err
+	}
+
+	prices, err := session.GetMarketItemPriceHistorySince(appID, marketHashName, time.Now().Add(-suggestRecentSales))
+	if err != nil {
+		return nil, err
+	}
+
+	data.RecentHistory = prices
+	for _, price := range prices {
+		data.RecentSales = append(data.RecentSales, uint64(math.Round(price.Price*100)))
+	}
+
+	return data, nil
+}
+
+// SuggestPrice gets the market data of the item and asks @strategy for prices.
+func (session *Session) SuggestPrice(appID uint64, marketHashName string, strategy PriceStrategy) (*PriceSuggestion, error) {
+	data, err := session.GetMarketPriceData(appID, marketHashName)
+	if err != nil {
+		return nil, err
+	}
+
+	return strategy.Suggest(data)
+}
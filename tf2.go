@@ -0,0 +1,70 @@
+package steam
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	TF2KillstreakNone = iota
+	TF2KillstreakBasic
+	TF2KillstreakSpecialized
+	TF2KillstreakProfessional
+)
+
+var tf2AttributeRegexp = regexp.MustCompile("^★?\\s*(Unusual Effect|Paint Color|Sheen|Killstreaker): (.+)$")
+
+// TF2Attributes are the TF2 item properties that can't be told from the market
+// name alone but are needed to price it.
+type TF2Attributes struct {
+	Unusual      string // Particle effect, e.g. "Burning Flames"
+	Killstreak   int    // One of TF2Killstreak*
+	Sheen        string // Specialized and professional killstreaks
+	Killstreaker string // Professional killstreaks
+	Paint        string
+}
+
+// ParseTF2Name reads what the name (or market hash name) of a TF2 item tells,
+// which is only the killstreak tier, descriptions are needed for the rest.
+func ParseTF2Name(name string) *TF2Attributes {
+	attributes := &TF2Attributes{}
+	switch {
+	case strings.Contains(name, "Professional Killstreak"):
+		attributes.Killstreak = TF2KillstreakProfessional
+	case strings.Contains(name, "Specialized Killstreak"):
+		attributes.Killstreak = TF2KillstreakSpecialized
+	case strings.Contains(name, "Killstreak"):
+		attributes.Killstreak = TF2KillstreakBasic
+	}
+
+	return attributes
+}
+
+// TF2Attributes parses the name and descriptions of a TF2 item.
+func (desc *EconItemDesc) TF2Attributes() *TF2Attributes {
+	attributes := ParseTF2Name(desc.MarketHashName)
+	for _, line := range desc.descriptionLines() {
+		m := tf2AttributeRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		switch m[1] {
+		case "Unusual Effect":
+			attributes.Unusual = m[2]
+		case "Paint Color":
+			attributes.Paint = m[2]
+		case "Sheen":
+			attributes.Sheen = m[2]
+		case "Killstreaker":
+			attributes.Killstreaker = m[2]
+		}
+	}
+
+	return attributes
+}
+
+// TF2Attributes only has the killstreak tier, search results carry no descriptions.
+func (item *MarketSearchItem) TF2Attributes() *TF2Attributes {
+	return ParseTF2Name(item.HashName)
+}
@@ -0,0 +1,84 @@
+package steam
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// endpointTimeouts maps URL path prefixes to the timeout of their requests.
+type endpointTimeouts struct {
+	timeouts map[string]time.Duration
+	mutex    sync.RWMutex
+}
+
+// SetEndpointTimeout sets the timeout of the requests whose URL path starts with
+// @pathPrefix, e.g. "/market/priceoverview/" or "/inventory/", the longest
+// matching prefix wins and 0 removes it.  The timeout of the http.Client given
+// to NewSession still applies to every request, so it should be the highest.
+// An earlier deadline set on the request context is kept.
+func (session *Session) SetEndpointTimeout(pathPrefix string, timeout time.Duration) {
+	session.timeouts.mutex.Lock()
+	defer session.timeouts.mutex.Unlock()
+
+	if session.timeouts.timeouts == nil {
+		session.timeouts.timeouts = make(map[string]time.Duration)
+	}
+
+	if timeout == 0 {
+		delete(session.timeouts.timeouts, pathPrefix)
+	} else {
+		session.timeouts.timeouts[pathPrefix] = timeout
+	}
+}
+
+func (timeouts *endpointTimeouts) lookup(path string) time.Duration {
+	timeouts.mutex.RLock()
+	defer timeouts.mutex.RUnlock()
+
+	var timeout time.Duration
+	longest := -1
+	for prefix, t := range timeouts.timeouts {
+		if len(prefix) > longest && strings.HasPrefix(path, prefix) {
+			timeout = t
+			longest = len(prefix)
+		}
+	}
+
+	return timeout
+}
+
+// closeHookBody calls onClose once the body is closed, for what must last until
+// the response is read (which is after RoundTrip returns).
+type closeHookBody struct {
+	io.ReadCloser
+	onClose func()
+	once    sync.Once
+}
+
+func (body *closeHookBody) Close() error {
+	err := body.ReadCloser.Close()
+	body.once.Do(body.onClose)
+	return err
+}
+
+// roundTrip runs @roundTrip with the timeout configured for @req.
+func (timeouts *endpointTimeouts) roundTrip(req *http.Request, roundTrip func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	timeout := timeouts.lookup(req.URL.Path)
+	if timeout == 0 {
+		return roundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := roundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &closeHookBody{ReadCloser: resp.Body, onClose: cancel}
+	return resp, nil
+}
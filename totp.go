@@ -2,10 +2,13 @@ package steam
 
 import (
 	"crypto/hmac"
+	"crypto/md5"
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
@@ -66,6 +69,29 @@ func GenerateConfirmationCode(identitySecret, tag string, current int64) (string
 	return base64.StdEncoding.EncodeToString(hmac.Sum(nil)), nil
 }
 
+// GenerateDeviceID derives the device id the mobile app would use for @sid,
+// this is what confirmations are tied to when the authenticator was set up
+// by this package (or by any tool using the same derivation).
+// Note: Earlier versions of this package derived it from the account name and
+// password instead, authenticators enrolled with EnableTwoFactor back then need
+// SetDeviceID(GenerateLegacyDeviceID(...)) for their confirmations to work.
+func GenerateDeviceID(sid SteamID) string {
+	sum := sha1.Sum([]byte(sid.ToString()))
+	h := hex.EncodeToString(sum[:])
+
+	return "android:" + h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// GenerateLegacyDeviceID is the device id earlier versions of this package used
+// for every login, see GenerateDeviceID.
+func GenerateLegacyDeviceID(accountName, password string) string {
+	sum := md5.Sum([]byte(accountName + password))
+	return fmt.Sprintf(
+		"android:%x-%x-%x-%x-%x",
+		sum[:2], sum[2:4], sum[4:6], sum[6:8], sum[8:10],
+	)
+}
+
 func GetTimeTip() (*ServerTimeTip, error) {
 	resp, err := http.Post("https://api.steampowered.com/ITwoFactorService/QueryTime/v1/", "application/x-www-form-urlencoded", nil)
 	if resp != nil {
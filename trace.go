@@ -0,0 +1,190 @@
+package steam
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// How much of the bodies a RequestDump keeps.
+const maxDumpBody = 64 * 1024
+
+// dumpScrubbedHeaders and dumpScrubbedParams are masked in request dumps.
+var (
+	dumpScrubbedHeaders = []string{"Cookie", "Set-Cookie", "Authorization"}
+	dumpScrubbedParams  = []string{
+		"key", "access_token", "oauth_token", "webcookie", "sessionid",
+		"password", "twofactorcode", "emailauth", "steamguard",
+	}
+)
+
+type traceContextKey struct{}
+
+// TraceError wraps the errors of a request with its trace ID.
+type TraceError struct {
+	TraceID string
+	Err     error
+}
+
+func (err *TraceError) Error() string {
+	return fmt.Sprintf("%v (trace %s)", err.Err, err.TraceID)
+}
+
+func (err *TraceError) Unwrap() error {
+	return err.Err
+}
+
+// RequestDump is a failed request, Request and Response are the HTTP/1.1 text of
+// both (Response is empty if there was none) with credentials masked.
+type RequestDump struct {
+	TraceID  string
+	Time     time.Time
+	Request  []byte
+	Response []byte
+	Err      error
+}
+
+// DebugSink receives the dumps of the failed requests, it's called from the request
+// goroutine so it must not block for long.
+type DebugSink interface {
+	Dump(dump *RequestDump)
+}
+
+type writerSink struct {
+	w io.Writer
+}
+
+func (sink *writerSink) Dump(dump *RequestDump) {
+	fmt.Fprintf(sink.w, "=== %s %s\n%s\n", dump.TraceID, dump.Time.Format(time.RFC3339), dump.Request)
+	if dump.Err != nil {
+		fmt.Fprintf(sink.w, "--- error: %v\n\n", dump.Err)
+	} else {
+		fmt.Fprintf(sink.w, "---\n%s\n\n", dump.Response)
+	}
+}
+
+// WriterSink writes the dumps to @w as text, @w must be safe for concurrent use
+// if the session makes concurrent requests.
+func WriterSink(w io.Writer) DebugSink {
+	return &writerSink{w}
+}
+
+// SetDebugSink dumps every failed request (transport errors and 4xx/5xx) to @sink,
+// nil disables it.
+func (session *Session) SetDebugSink(sink DebugSink) {
+	session.debugSink = sink
+}
+
+// WithTraceID makes the requests made with @ctx use @traceID instead of a generated one.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, traceID)
+}
+
+// TraceID returns the trace ID of @ctx, the one of a request is set by the session
+// before it's sent, see also ResponseTraceID.
+func TraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceContextKey{}).(string)
+	return traceID
+}
+
+// ResponseTraceID returns the trace ID of the request @resp answers.
+func ResponseTraceID(resp *http.Response) string {
+	if resp == nil || resp.Request == nil {
+		return ""
+	}
+
+	return TraceID(resp.Request.Context())
+}
+
+func newTraceID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// withTrace returns @req with a trace ID in its context.
+func withTrace(req *http.Request) (*http.Request, string) {
+	if traceID := TraceID(req.Context()); len(traceID) != 0 {
+		return req, traceID
+	}
+
+	traceID := newTraceID()
+	return req.WithContext(WithTraceID(req.Context(), traceID)), traceID
+}
+
+func scrubDumpValues(values url.Values) {
+	for _, name := range dumpScrubbedParams {
+		if _, ok := values[name]; ok {
+			values.Set(name, "***")
+		}
+	}
+}
+
+func writeDumpHeader(buf *bytes.Buffer, header http.Header) {
+	header = header.Clone()
+	for _, name := range dumpScrubbedHeaders {
+		if _, ok := header[name]; ok {
+			header.Set(name, "***")
+		}
+	}
+
+	header.Write(buf)
+	buf.WriteString("\r\n")
+}
+
+func dumpRequest(req *http.Request) []byte {
+	u := *req.URL
+	query := u.Query()
+	scrubDumpValues(query)
+	u.RawQuery = query.Encode()
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s %s HTTP/1.1\r\n", req.Method, u.String())
+	writeDumpHeader(buf, req.Header)
+
+	if req.GetBody == nil {
+		return buf.Bytes()
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return buf.Bytes()
+	}
+	defer body.Close()
+
+	b, _ := ioutil.ReadAll(io.LimitReader(body, maxDumpBody))
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(b)); err == nil {
+			scrubDumpValues(values)
+			b = []byte(values.Encode())
+		}
+	}
+
+	buf.Write(b)
+	return buf.Bytes()
+}
+
+// dumpResponse reads the start of the body of @resp, which is put back.
+func dumpResponse(resp *http.Response) []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "HTTP/1.1 %s\r\n", resp.Status)
+	writeDumpHeader(buf, resp.Header)
+
+	body := resp.Body
+	b, _ := ioutil.ReadAll(io.LimitReader(body, maxDumpBody))
+	resp.Body = &closeHookBody{
+		ReadCloser: ioutil.NopCloser(io.MultiReader(bytes.NewReader(b), body)),
+		onClose:    func() { body.Close() },
+	}
+
+	buf.Write(b)
+	return buf.Bytes()
+}
@@ -55,24 +55,16 @@ var (
 
 	apiGetTradeOffer     = "https://api.steampowered.com/IEconService/GetTradeOffer/v1/?"
 	apiGetTradeOffers    = "https://api.steampowered.com/IEconService/GetTradeOffers/v1/?"
+	apiGetOffersSummary  = "https://api.steampowered.com/IEconService/GetTradeOffersSummary/v1/?"
 	apiDeclineTradeOffer = "https://api.steampowered.com/IEconService/DeclineTradeOffer/v1/"
 	apiCancelTradeOffer  = "https://api.steampowered.com/IEconService/CancelTradeOffer/v1/"
 
-	ErrReceiptMatch        = errors.New("unable to match items in trade receipt")
-	ErrCannotAcceptActive  = errors.New("unable to accept a non-active trade")
-	ErrCannotFindOfferInfo = errors.New("unable to match data from trade offer url")
+	ErrReceiptMatch         = errors.New("unable to match items in trade receipt")
+	ErrCannotAcceptActive   = errors.New("unable to accept a non-active trade")
+	ErrCannotFindOfferInfo  = errors.New("unable to match data from trade offer url")
+	ErrNoTradeOffersSummary = errors.New("no trade offers summary in response")
 )
 
-type EconItem struct {
-	AssetID    uint64 `json:"assetid,string,omitempty"`
-	InstanceID uint64 `json:"instanceid,string,omitempty"`
-	ClassID    uint64 `json:"classid,string,omitempty"`
-	AppID      uint32 `json:"appid"`
-	ContextID  uint64 `json:"contextid,string"`
-	Amount     uint16 `json:"amount,string"`
-	Missing    bool   `json:"missing,omitempty"`
-}
-
 type EconDesc struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
@@ -91,6 +83,13 @@ type EconAction struct {
 	Name string `json:"name"`
 }
 
+// EconAppData is only set by some games (e.g. TF2), the values are the ones of the
+// item schema, see the schema package.
+type EconAppData struct {
+	DefIndex string `json:"def_index"`
+	Quality  string `json:"quality"`
+}
+
 type EconItemDesc struct {
 	ClassID         uint64        `json:"classid,string"`    // for matching with EconItem
 	InstanceID      uint64        `json:"instanceid,string"` // for matching with EconItem
@@ -105,8 +104,10 @@ type EconItemDesc struct {
 	MarketHashName  string        `json:"market_hash_name"`
 	Comodity        bool          `json:"comodity"`
 	Actions         []*EconAction `json:"actions"`
+	MarketActions   []*EconAction `json:"market_actions"`
 	Tags            []*EconTag    `json:"tags"`
 	Descriptions    []*EconDesc   `json:"descriptions"`
+	AppData         *EconAppData  `json:"app_data"`
 }
 
 type TradeOffer struct {
@@ -127,16 +128,33 @@ type TradeOffer struct {
 }
 
 type TradeOfferResponse struct {
+	RawResponse
+
 	Offer          *TradeOffer     `json:"offer"`                 // GetTradeOffer
 	SentOffers     []*TradeOffer   `json:"trade_offers_sent"`     // GetTradeOffers
 	ReceivedOffers []*TradeOffer   `json:"trade_offers_received"` // GetTradeOffers
-	Descriptions   []*EconItemDesc `json:"descriptions"`          // GetTradeOffers
+	Descriptions   []*EconItemDesc `json:"descriptions"`          // GetTradeOffers, GetTradeOfferWithDescriptions
 }
 
 type APIResponse struct {
+	RawResponse
+
 	Inner *TradeOfferResponse `json:"response"`
 }
 
+type TradeOffersSummary struct {
+	PendingReceived    uint32 `json:"pending_received_count"`
+	NewReceived        uint32 `json:"new_received_count"`
+	UpdatedReceived    uint32 `json:"updated_received_count"`
+	HistoricalReceived uint32 `json:"historical_received_count"`
+	PendingSent        uint32 `json:"pending_sent_count"`
+	NewlyAcceptedSent  uint32 `json:"newly_accepted_sent_count"`
+	UpdatedSent        uint32 `json:"updated_sent_count"`
+	HistoricalSent     uint32 `json:"historical_sent_count"`
+	EscrowReceived     uint32 `json:"escrow_received_count"`
+	EscrowSent         uint32 `json:"escrow_sent_count"`
+}
+
 func (session *Session) GetTradeOffer(id uint64) (*TradeOffer, error) {
 	resp, err := session.client.Get(apiGetTradeOffer + url.Values{
 		"key":          {session.apiKey},
@@ -150,11 +168,46 @@ func (session *Session) GetTradeOffer(id uint64) (*TradeOffer, error) {
 		return nil, err
 	}
 
+	sample := newPayloadSample(resp.Body)
 	var response APIResponse
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIBody(resp, sample, &response); err != nil {
 		return nil, err
 	}
 
+	if response.Inner == nil || response.Inner.Offer == nil {
+		return nil, sample.error("no offer in response")
+	}
+
+	return response.Inner.Offer, nil
+}
+
+// GetTradeOfferWithDescriptions is GetTradeOffer with Desc set on the items.
+func (session *Session) GetTradeOfferWithDescriptions(id uint64) (*TradeOffer, error) {
+	resp, err := session.client.Get(apiGetTradeOffer + url.Values{
+		"key":              {session.apiKey},
+		"tradeofferid":     {strconv.FormatUint(id, 10)},
+		"get_descriptions": {"1"},
+		"language":         {session.language},
+	}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sample := newPayloadSample(resp.Body)
+	var response APIResponse
+	if err = session.decodeAPIBody(resp, sample, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Inner == nil || response.Inner.Offer == nil {
+		return nil, sample.error("no offer in response")
+	}
+
+	response.Inner.mergeDescriptions()
 	return response.Inner.Offer, nil
 }
 
@@ -180,11 +233,15 @@ func (session *Session) GetTradeOffers(filter uint32, timeCutOff time.Time) (*Tr
 
 	if testBit(filter, TradeFilterItemDescriptions) {
 		params.Set("get_descriptions", "1")
+		params.Set("language", session.language)
 	}
 
 	if testBit(filter, TradeFilterHistoricalOnly) {
 		params.Set("historical_only", "1")
 		params.Set("time_historical_cutoff", strconv.FormatInt(timeCutOff.Unix(), 10))
+	} else if testBit(filter, TradeFilterActiveOnly) && !timeCutOff.IsZero() {
+		// Also return offers that stopped being active since then.
+		params.Set("time_historical_cutoff", strconv.FormatInt(timeCutOff.Unix(), 10))
 	}
 
 	resp, err := session.client.Get(apiGetTradeOffers + params.Encode())
@@ -196,14 +253,89 @@ func (session *Session) GetTradeOffers(filter uint32, timeCutOff time.Time) (*Tr
 		return nil, err
 	}
 
+	sample := newPayloadSample(resp.Body)
 	var response APIResponse
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIBody(resp, sample, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Inner == nil {
+		return nil, sample.error("no response")
+	}
+
+	response.Inner.RawResponse = response.RawResponse
+	response.Inner.mergeDescriptions()
+	return response.Inner, nil
+}
+
+// mergeDescriptions sets Desc on every item of every offer that has one
+// in Descriptions (only there if TradeFilterItemDescriptions was used).
+func (response *TradeOfferResponse) mergeDescriptions() {
+	if len(response.Descriptions) == 0 {
+		return
+	}
+
+	descriptions := make(map[string]*EconItemDesc, len(response.Descriptions))
+	for _, desc := range response.Descriptions {
+		descriptions[fmt.Sprintf("%d_%d", desc.ClassID, desc.InstanceID)] = desc
+	}
+
+	merge := func(items []*EconItem) {
+		for _, item := range items {
+			item.Desc = descriptions[fmt.Sprintf("%d_%d", item.ClassID, item.InstanceID)]
+		}
+	}
+
+	if response.Offer != nil {
+		merge(response.Offer.RecvItems)
+		merge(response.Offer.SendItems)
+	}
+
+	for _, offer := range response.SentOffers {
+		merge(offer.RecvItems)
+		merge(offer.SendItems)
+	}
+
+	for _, offer := range response.ReceivedOffers {
+		merge(offer.RecvItems)
+		merge(offer.SendItems)
+	}
+}
+
+// GetTradeOffersSummary returns the pending offer counts, "new" and "updated" counts
+// are relative to @lastVisit, pass a zero time to use the time Steam has on record.
+func (session *Session) GetTradeOffersSummary(lastVisit time.Time) (*TradeOffersSummary, error) {
+	params := url.Values{
+		"key": {session.apiKey},
+	}
+	if !lastVisit.IsZero() {
+		params.Set("time_last_visit", strconv.FormatInt(lastVisit.Unix(), 10))
+	}
+
+	resp, err := session.client.Get(apiGetOffersSummary + params.Encode())
+
+	type Response struct {
+		Inner *TradeOffersSummary `json:"response"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return nil, err
 	}
 
+	if response.Inner == nil {
+		return nil, ErrNoTradeOffersSummary
+	}
+
 	return response.Inner, nil
 }
 
+// HasPending reports whether there is anything worth fetching the full offer list for.
+func (summary *TradeOffersSummary) HasPending() bool {
+	return summary.PendingReceived != 0 || summary.NewReceived != 0 || summary.UpdatedReceived != 0 ||
+		summary.NewlyAcceptedSent != 0 || summary.UpdatedSent != 0
+}
+
 func (session *Session) GetMyTradeToken() (string, error) {
 	resp, err := session.client.Get("https://steamcommunity.com/my/tradeoffers/privacy")
 	if resp != nil {
@@ -286,6 +418,14 @@ func (session *Session) GetEscrowGuardInfo(sid SteamID, token string) (*EscrowSt
 }
 
 func (session *Session) SendTradeOffer(offer *TradeOffer, sid SteamID, token string) error {
+	if len(token) != 0 && !tradeTokenRegexp.MatchString(token) {
+		return ErrInvalidTradeToken
+	}
+
+	if err := ValidateOfferMessage(offer.Message); err != nil {
+		return err
+	}
+
 	content := map[string]interface{}{
 		"newversion": true,
 		"version":    3,
@@ -345,7 +485,7 @@ func (session *Session) SendTradeOffer(offer *TradeOffer, sid SteamID, token str
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeJSON(resp.Body, &response); err != nil {
 		return err
 	}
 
@@ -377,7 +517,7 @@ func (session *Session) SendTradeOffer(offer *TradeOffer, sid SteamID, token str
 }
 
 func (session *Session) GetTradeReceivedItems(receiptID uint64) ([]*InventoryItem, error) {
-	resp, err := session.client.Get(fmt.Sprintf("https://steamcommunity.com/trade/%d/receipt", receiptID))
+	resp, err := session.client.Get(fmt.Sprintf("https://steamcommunity.com/trade/%d/receipt?l=%s", receiptID, url.QueryEscape(session.language)))
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -413,48 +553,92 @@ func (session *Session) GetTradeReceivedItems(receiptID uint64) ([]*InventoryIte
 	return items, nil
 }
 
+// DeclineTradeOffer goes through the Web API, it only needs the API key, see
+// DeclineTradeOfferCommunity for sessions without one.
 func (session *Session) DeclineTradeOffer(id uint64) error {
 	resp, err := session.client.PostForm(apiDeclineTradeOffer, url.Values{
 		"key":          {session.apiKey},
 		"tradeofferid": {strconv.FormatUint(id, 10)},
 	})
-	if resp != nil {
-		resp.Body.Close()
-	}
-
-	if err != nil {
-		return err
-	}
-
-	result := resp.Header.Get("x-eresult")
-	if result != "1" {
-		return fmt.Errorf("cannot decline trade: %s", result)
+	if err = session.decodeAPIResponse(resp, err, &struct{}{}); err != nil {
+		return fmt.Errorf("cannot decline trade: %w", err)
 	}
 
 	return nil
 }
 
+// CancelTradeOffer goes through the Web API, it only needs the API key, see
+// CancelTradeOfferCommunity for sessions without one.
 func (session *Session) CancelTradeOffer(id uint64) error {
 	resp, err := session.client.PostForm(apiCancelTradeOffer, url.Values{
 		"key":          {session.apiKey},
 		"tradeofferid": {strconv.FormatUint(id, 10)},
 	})
+	if err = session.decodeAPIResponse(resp, err, &struct{}{}); err != nil {
+		return fmt.Errorf("cannot cancel trade: %w", err)
+	}
+
+	return nil
+}
+
+// tradeOfferAction posts @action (cancel or decline) for the offer using the web session.
+func (session *Session) tradeOfferAction(id uint64, action string) error {
+	tid := strconv.FormatUint(id, 10)
+	postURL := "https://steamcommunity.com/tradeoffer/" + tid
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		postURL+"/"+action,
+		strings.NewReader(url.Values{
+			"sessionid": {session.sessionID},
+		}.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Referer", postURL)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := session.client.Do(req)
 	if resp != nil {
-		resp.Body.Close()
+		defer resp.Body.Close()
 	}
 
 	if err != nil {
 		return err
 	}
 
-	result := resp.Header.Get("x-eresult")
-	if result != "1" {
-		return fmt.Errorf("cannot cancel trade: %s", result)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot %s trade: http error: %d", action, resp.StatusCode)
+	}
+
+	type Response struct {
+		TradeOfferID string `json:"tradeofferid"`
+	}
+
+	var response Response
+	if err = session.decodeJSON(resp.Body, &response); err != nil {
+		return err
+	}
+
+	if response.TradeOfferID != tid {
+		return fmt.Errorf("cannot %s trade", action)
 	}
 
 	return nil
 }
 
+// CancelTradeOfferCommunity is CancelTradeOffer through the web session, without the API key.
+func (session *Session) CancelTradeOfferCommunity(id uint64) error {
+	return session.tradeOfferAction(id, "cancel")
+}
+
+// DeclineTradeOfferCommunity is DeclineTradeOffer through the web session, without the API key.
+func (session *Session) DeclineTradeOfferCommunity(id uint64) error {
+	return session.tradeOfferAction(id, "decline")
+}
+
 func (session *Session) AcceptTradeOffer(id uint64) error {
 	tid := strconv.FormatUint(id, 10)
 	postURL := "https://steamcommunity.com/tradeoffer/" + tid
@@ -493,7 +677,7 @@ func (session *Session) AcceptTradeOffer(id uint64) error {
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeJSON(resp.Body, &response); err != nil {
 		return err
 	}
 
@@ -512,10 +696,18 @@ func (offer *TradeOffer) Accept(session *Session) error {
 	return session.AcceptTradeOffer(offer.ID)
 }
 
+// Cancel cancels our offers and declines the others, through the Web API when
+// the session has an API key.
 func (offer *TradeOffer) Cancel(session *Session) error {
-	if offer.IsOurOffer {
+	withKey := len(session.apiKey) != 0
+	switch {
+	case offer.IsOurOffer && withKey:
 		return session.CancelTradeOffer(offer.ID)
+	case offer.IsOurOffer:
+		return session.CancelTradeOfferCommunity(offer.ID)
+	case withKey:
+		return session.DeclineTradeOffer(offer.ID)
 	}
 
-	return session.DeclineTradeOffer(offer.ID)
+	return session.DeclineTradeOfferCommunity(offer.ID)
 }
@@ -0,0 +1,176 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	TradeActionCancel = iota
+	TradeActionDecline
+)
+
+var ErrManagerStarted = errors.New("trade offer manager already started")
+
+// TradeOfferPolicy decides on received offers, offers it matches are declined.
+type TradeOfferPolicy struct {
+	Name  string
+	Match func(*TradeOffer) bool
+}
+
+// TradeOfferAction is what is reported to the audit callback, Err is set
+// if the action failed.
+type TradeOfferAction struct {
+	Offer  *TradeOffer
+	Action int
+	Reason string
+	Err    error
+}
+
+// DeclineGiftRequests matches offers asking for our items while giving nothing.
+var DeclineGiftRequests = TradeOfferPolicy{
+	Name: "gift request",
+	Match: func(offer *TradeOffer) bool {
+		return len(offer.SendItems) != 0 && len(offer.RecvItems) == 0
+	},
+}
+
+// TradeOfferManager applies expiry and decline policies to active offers, it can
+// either be driven by calling Process() or by Start() which calls it every Interval.
+type TradeOfferManager struct {
+	session *Session
+
+	// CancelAfter cancels our offers that are active for longer than this, zero disables it.
+	CancelAfter time.Duration
+	// Policies are checked in order against every active received offer.
+	Policies []TradeOfferPolicy
+	// OnAction is called for every cancel/decline attempted, may be nil.
+	OnAction func(*TradeOfferAction)
+	// Notifier is notified of every cancel/decline attempted, may be nil.
+	Notifier Notifier
+	Interval time.Duration
+	// Jitter moves each wait by up to this fraction of Interval, see Jitter.
+	Jitter float64
+	Clock  Clock
+	// OnError is called with the errors of Start and of Notifier, may be nil.
+	OnError func(error)
+
+	lifecycle
+}
+
+// NewTradeOfferManager @clock may be nil for SystemClock.
+func (session *Session) NewTradeOfferManager(interval time.Duration, clock Clock) *TradeOfferManager {
+	return &TradeOfferManager{
+		session:  session,
+		Interval: interval,
+		Clock:    clockOrSystem(clock),
+	}
+}
+
+func (manager *TradeOfferManager) audit(offer *TradeOffer, action int, reason string, err error) {
+	info := &TradeOfferAction{
+		Offer:  offer,
+		Action: action,
+		Reason: reason,
+		Err:    err,
+	}
+
+	if manager.OnAction != nil {
+		manager.OnAction(info)
+	}
+
+	if manager.Notifier != nil {
+		verb, done := "cancel", "Cancelled"
+		if action == TradeActionDecline {
+			verb, done = "decline", "Declined"
+		}
+
+		text := fmt.Sprintf("%s trade offer %d (%s)", done, offer.ID, reason)
+		if err != nil {
+			text = fmt.Sprintf("Could not %s trade offer %d (%s): %v", verb, offer.ID, reason, err)
+		}
+
+		notifyErr := manager.Notifier.Notify(&Notification{
+			Source: NotificationTradeOffer,
+			Title:  "Trade offer " + strconv.FormatUint(offer.ID, 10),
+			Text:   text,
+			Time:   clockOrSystem(manager.Clock).Now(),
+			Data:   info,
+		})
+		if notifyErr != nil && manager.OnError != nil {
+			manager.OnError(notifyErr)
+		}
+	}
+}
+
+func (manager *TradeOfferManager) matchPolicy(offer *TradeOffer) (string, bool) {
+	for _, policy := range manager.Policies {
+		if policy.Match(offer) {
+			return policy.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// Process goes over the active offers once, errors cancelling or declining
+// individual offers are only reported to OnAction.  Offers are cancelled and
+// declined with TradeOffer.Cancel, through the Web API when the session has an
+// API key.
+func (manager *TradeOfferManager) Process() error {
+	if manager.CancelAfter == 0 && len(manager.Policies) == 0 {
+		return nil
+	}
+
+	resp, err := manager.session.GetTradeOffers(
+		TradeFilterSentOffers|TradeFilterRecvOffers|TradeFilterActiveOnly,
+		time.Time{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if manager.CancelAfter != 0 {
+		for _, offer := range resp.SentOffers {
+			if offer.State != TradeStateActive {
+				continue
+			}
+
+			if clockOrSystem(manager.Clock).Now().Sub(time.Unix(offer.Created, 0)) > manager.CancelAfter {
+				err := offer.Cancel(manager.session)
+				manager.audit(offer, TradeActionCancel, "expired", err)
+			}
+		}
+	}
+
+	for _, offer := range resp.ReceivedOffers {
+		if offer.State != TradeStateActive {
+			continue
+		}
+
+		if name, ok := manager.matchPolicy(offer); ok {
+			err := offer.Cancel(manager.session)
+			manager.audit(offer, TradeActionDecline, name, err)
+		}
+	}
+
+	return nil
+}
+
+// Start calls Process every Interval until Stop or @ctx is done.
+func (manager *TradeOfferManager) Start(ctx context.Context) error {
+	return manager.start(ctx, ErrManagerStarted, nil, func(stop chan struct{}) {
+		for {
+			if err := manager.Process(); err != nil && manager.OnError != nil {
+				manager.OnError(err)
+			}
+
+			if !wait(manager.Clock, stop, Jitter(manager.Interval, manager.Jitter)) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package steam
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+var (
+	tradeTokenRegexp = regexp.MustCompile("^[a-zA-Z0-9_-]{8}$")
+
+	ErrInvalidTradeURL   = errors.New("invalid trade offer url")
+	ErrInvalidTradeToken = errors.New("invalid trade offer token")
+)
+
+type TradeURL struct {
+	Partner SteamID
+	Token   string
+}
+
+// ParseTradeURL validates a trade offer url the way users give it, i.e.
+//
+//	https://steamcommunity.com/tradeoffer/new/?partner=<account id>&token=<token>
+//
+// and returns the partner SteamID and the token.
+func ParseTradeURL(rawURL string) (SteamID, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, "", ErrInvalidTradeURL
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return 0, "", ErrInvalidTradeURL
+	}
+
+	if u.Host != "steamcommunity.com" && u.Host != "www.steamcommunity.com" {
+		return 0, "", ErrInvalidTradeURL
+	}
+
+	if u.Path != "/tradeoffer/new/" && u.Path != "/tradeoffer/new" {
+		return 0, "", ErrInvalidTradeURL
+	}
+
+	query := u.Query()
+	if len(query["partner"]) != 1 || len(query["token"]) != 1 {
+		return 0, "", ErrInvalidTradeURL
+	}
+
+	accountID, err := strconv.ParseUint(query.Get("partner"), 10, 32)
+	if err != nil || accountID == 0 {
+		return 0, "", ErrInvalidTradeURL
+	}
+
+	token := query.Get("token")
+	if !tradeTokenRegexp.MatchString(token) {
+		return 0, "", ErrInvalidTradeToken
+	}
+
+	var sid SteamID
+	sid.ParseDefaults(uint32(accountID))
+	return sid, token, nil
+}
+
+func (tradeURL *TradeURL) Canonical() string {
+	return "https://steamcommunity.com/tradeoffer/new/?" + url.Values{
+		"partner": {strconv.FormatUint(uint64(tradeURL.Partner.GetAccountID()), 10)},
+		"token":   {tradeURL.Token},
+	}.Encode()
+}
+
+// GetTradeURL returns our own trade offer url in canonical form.
+func (session *Session) GetTradeURL() (string, error) {
+	token, err := session.GetMyTradeToken()
+	if err != nil {
+		return "", err
+	}
+
+	tradeURL := TradeURL{
+		Partner: session.GetSteamID(),
+		Token:   token,
+	}
+	return tradeURL.Canonical(), nil
+}
+
+// SendTradeOfferURL is like SendTradeOffer but takes the partner's trade url.
+func (session *Session) SendTradeOfferURL(offer *TradeOffer, tradeURL string) error {
+	sid, token, err := ParseTradeURL(tradeURL)
+	if err != nil {
+		return err
+	}
+
+	return session.SendTradeOffer(offer, sid, token)
+}
@@ -1,8 +1,8 @@
 package steam
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
 	"net/url"
 	"strconv"
 	"time"
@@ -23,15 +23,41 @@ type TwoFactorInfo struct {
 type FinalizeTwoFactorInfo struct {
 	Status     uint32 `json:"status"`
 	ServerTime uint64 `json:"server_time,string"`
+	WantMore   bool   `json:"want_more"`
+	Success    bool   `json:"success"`
 }
 
+const (
+	twoFactorStatusOK             = 1
+	twoFactorStatusNoPhone        = 2
+	twoFactorStatusAlreadyEnabled = 29
+	twoFactorStatusBadAuthCode    = 88
+	twoFactorStatusBadSMSCode     = 89
+
+	// How many codes Steam may ask for while finalizing before we give up.
+	finalizeTwoFactorMaxTries = 30
+	// How many codes in a row Steam may refuse (e.g. the clock being off by a
+	// window) while finalizing with the secret before we give up.
+	finalizeTwoFactorMaxBadCodes = 3
+)
+
 const (
 	enableTwoFactorURL   = "https://api.steampowered.com/ITwoFactorService/AddAuthenticator/v1/"
 	finalizeTwoFactorURL = "https://api.steampowered.com/ITwoFactorService/FinalizeAddAuthenticator/v1/"
 	disableTwoFactorURL  = "https://api.steampowered.com/ITwoFactorService/RemoveAuthenticator/v1/"
 )
 
-var ErrCannotDisable = errors.New("unable to process disable two factor request")
+var (
+	ErrCannotDisable              = errors.New("unable to process disable two factor request")
+	ErrCannotEnableTwoFactor      = errors.New("unable to enable two factor")
+	ErrTwoFactorNoPhone           = errors.New("a phone number must be attached to enable two factor")
+	ErrTwoFactorAlreadyEnabled    = errors.New("two factor is already enabled on this account")
+	ErrInvalidActivationCode      = errors.New("invalid SMS activation code")
+	ErrInvalidAuthenticatorCode   = errors.New("invalid authenticator code")
+	ErrCannotFinalizeTwoFactor    = errors.New("unable to finalize two factor")
+	ErrInvalidRevocationCode      = errors.New("invalid revocation code")
+	ErrRevocationAttemptsExceeded = errors.New("no revocation attempts remaining")
+)
 
 func (session *Session) EnableTwoFactor() (*TwoFactorInfo, error) {
 	resp, err := session.client.PostForm(enableTwoFactorURL, url.Values{
@@ -42,54 +68,116 @@ func (session *Session) EnableTwoFactor() (*TwoFactorInfo, error) {
 		"device_identifier":  {session.deviceID},
 		"sms_phone_id":       {"1"},
 	})
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return nil, err
-	}
 
 	type Response struct {
 		Inner *TwoFactorInfo `json:"response"`
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return nil, err
 	}
 
+	if response.Inner == nil {
+		return nil, ErrCannotEnableTwoFactor
+	}
+
+	switch response.Inner.Status {
+	case twoFactorStatusOK:
+	case twoFactorStatusNoPhone:
+		return nil, ErrTwoFactorNoPhone
+	case twoFactorStatusAlreadyEnabled:
+		return nil, ErrTwoFactorAlreadyEnabled
+	default:
+		return nil, fmt.Errorf("cannot enable two factor: status %d", response.Inner.Status)
+	}
+
 	return response.Inner, nil
 }
 
 func (session *Session) FinalizeTwoFactor(authCode, mobileCode string) (*FinalizeTwoFactorInfo, error) {
+	return session.finalizeTwoFactor(authCode, mobileCode, time.Now().Unix())
+}
+
+// finalizeTwoFactor @authTime is the time @authCode was generated for.
+func (session *Session) finalizeTwoFactor(authCode, mobileCode string, authTime int64) (*FinalizeTwoFactorInfo, error) {
 	resp, err := session.client.PostForm(finalizeTwoFactorURL, url.Values{
 		"steamid":            {session.oauth.SteamID.ToString()},
 		"access_token":       {session.oauth.Token},
-		"authenticator_time": {strconv.FormatInt(time.Now().Unix(), 10)},
+		"authenticator_time": {strconv.FormatInt(authTime, 10)},
 		"authenticator_code": {authCode},
 		"activation_code":    {mobileCode},
 	})
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return nil, err
-	}
 
 	type Response struct {
 		Inner *FinalizeTwoFactorInfo `json:"response"`
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return nil, err
 	}
 
+	if response.Inner == nil {
+		return nil, ErrCannotFinalizeTwoFactor
+	}
+
+	switch response.Inner.Status {
+	case twoFactorStatusBadSMSCode:
+		return nil, ErrInvalidActivationCode
+	case twoFactorStatusBadAuthCode:
+		return nil, ErrInvalidAuthenticatorCode
+	}
+
 	return response.Inner, nil
 }
 
+// FinalizeTwoFactorWithSecret finalizes using @sharedSecret (from EnableTwoFactor) to
+// generate the authenticator codes: Steam may ask for more than one code (want_more)
+// before it considers the authenticator set up, so this keeps going until it's done.
+// A refused code is retried with the next window a few times, in case the clock is off.
+// Note: Save the revocation code from EnableTwoFactor before calling this, it's the only
+// way to remove the authenticator without the secrets.
+func (session *Session) FinalizeTwoFactorWithSecret(sharedSecret, mobileCode string, timeOffset time.Duration) (*FinalizeTwoFactorInfo, error) {
+	current := time.Now().Add(timeOffset).Unix()
+	badCodes := 0
+	for tries := 0; tries < finalizeTwoFactorMaxTries; tries++ {
+		authCode, err := GenerateTwoFactorCode(sharedSecret, current)
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := session.finalizeTwoFactor(authCode, mobileCode, current)
+		if err == ErrInvalidAuthenticatorCode {
+			if badCodes++; badCodes >= finalizeTwoFactorMaxBadCodes {
+				return nil, err
+			}
+
+			current += 30
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		badCodes = 0
+
+		if !info.WantMore {
+			if !info.Success {
+				return nil, ErrCannotFinalizeTwoFactor
+			}
+
+			return info, nil
+		}
+
+		// Next code is for the next 30 second window.
+		current += 30
+	}
+
+	return nil, ErrCannotFinalizeTwoFactor
+}
+
 func (session *Session) DisableTwoFactor(revocationCode string) error {
 	resp, err := session.client.PostForm(disableTwoFactorURL, url.Values{
 		"steamid":           {session.oauth.SteamID.ToString()},
@@ -97,29 +185,35 @@ func (session *Session) DisableTwoFactor(revocationCode string) error {
 		"revocation_code":   {revocationCode},
 		"steamguard_scheme": {"1"},
 	})
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-
-	if err != nil {
-		return err
-	}
 
 	type Disabled struct {
-		Success bool `json:"success"`
+		Success           bool    `json:"success"`
+		AttemptsRemaining *uint32 `json:"revocation_attempts_remaining"`
 	}
 	type Response struct {
 		Inner *Disabled `json:"response"`
 	}
 
 	var response Response
-	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
 		return err
 	}
 
-	if !response.Inner.Success {
+	if response.Inner == nil {
 		return ErrCannotDisable
 	}
 
+	if !response.Inner.Success {
+		if response.Inner.AttemptsRemaining == nil {
+			return ErrCannotDisable
+		}
+
+		if *response.Inner.AttemptsRemaining == 0 {
+			return ErrRevocationAttemptsExceeded
+		}
+
+		return ErrInvalidRevocationCode
+	}
+
 	return nil
 }
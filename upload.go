@@ -0,0 +1,109 @@
+package steam
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+// Upload.FileType values (EWorkshopFileType).
+const (
+	PublishedFileTypeArt        = 3
+	PublishedFileTypeScreenshot = 5
+)
+
+// Upload.Visibility values.
+const (
+	PublishedFileVisibilityPublic = iota
+	PublishedFileVisibilityFriendsOnly
+	PublishedFileVisibilityPrivate
+	PublishedFileVisibilityUnlisted
+)
+
+var ErrCannotUpload = errors.New("unable to upload file")
+
+// Upload is an image to publish on the community, Title is the caption of
+// screenshots.  AppID is the game it is about, 0 for none.
+type Upload struct {
+	FileType    int
+	AppID       uint32
+	Title       string
+	Description string
+	Visibility  int
+	Tags        []string
+	FileName    string
+	File        io.Reader
+}
+
+// UploadFile publishes @upload and returns the ID of the published file, see
+// GetPublishedFileDetails.
+func (session *Session) UploadFile(upload *Upload) (uint64, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	fields := [][2]string{
+		{"sessionid", session.sessionID},
+		{"id", "0"},
+		{"file_type", strconv.Itoa(upload.FileType)},
+		{"consumer_app_id", strconv.FormatUint(uint64(upload.AppID), 10)},
+		{"title", upload.Title},
+		{"description", upload.Description},
+		{"visibility", strconv.Itoa(upload.Visibility)},
+		{"agree_terms", "on"},
+	}
+	for _, tag := range upload.Tags {
+		fields = append(fields, [2]string{"tags[]", tag})
+	}
+
+	for _, field := range fields {
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return 0, err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", upload.FileName)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = io.Copy(part, upload.File); err != nil {
+		return 0, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "https://steamcommunity.com/sharedfiles/publish", body)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Add("Content-Type", writer.FormDataContentType())
+	req.Header.Add("Referer", fmt.Sprintf("https://steamcommunity.com/sharedfiles/edititem/%d/%d/", upload.AppID, upload.FileType))
+
+	resp, err := session.client.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	// We end up on the page of the published file.
+	id, err := strconv.ParseUint(resp.Request.URL.Query().Get("id"), 10, 64)
+	if err != nil || id == 0 {
+		return 0, ErrCannotUpload
+	}
+
+	return id, nil
+}
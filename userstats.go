@@ -0,0 +1,374 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	apiGetUserStatsForGame   = "https://api.steampowered.com/ISteamUserStats/GetUserStatsForGame/v2/?"
+	apiGetPlayerAchievements = "https://api.steampowered.com/ISteamUserStats/GetPlayerAchievements/v1/?"
+	apiGetGlobalAchievements = "https://api.steampowered.com/ISteamUserStats/GetGlobalAchievementPercentagesForApp/v2/?"
+	apiGetSchemaForGame      = "https://api.steampowered.com/ISteamUserStats/GetSchemaForGame/v2/?"
+	apiGetCurrentPlayers     = "https://api.steampowered.com/ISteamUserStats/GetNumberOfCurrentPlayers/v1/?"
+)
+
+// ErrNoUserStats is returned when Steam answers without the stats, e.g. for a
+// private profile or an app the user doesn't own.
+var ErrNoUserStats = errors.New("no user stats in response")
+
+// How long GetGameSchema and GetGlobalAchievementPercentages re-use what they
+// previously fetched for an app.
+var (
+	GameSchemaCacheTime         = 24 * time.Hour
+	GlobalAchievementsCacheTime = time.Hour
+)
+
+type UserStat struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+type UserStatAchievement struct {
+	Name     string `json:"name"`
+	Achieved uint32 `json:"achieved"`
+}
+
+type UserStatsForGame struct {
+	SteamID      SteamID                `json:"steamID,string"`
+	GameName     string                 `json:"gameName"`
+	Stats        []*UserStat            `json:"stats"`
+	Achievements []*UserStatAchievement `json:"achievements"`
+}
+
+type PlayerAchievement struct {
+	APIName     string `json:"apiname"`
+	Achieved    uint32 `json:"achieved"`
+	UnlockTime  int64  `json:"unlocktime"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type PlayerAchievements struct {
+	SteamID      SteamID              `json:"steamID,string"`
+	GameName     string               `json:"gameName"`
+	Achievements []*PlayerAchievement `json:"achievements"`
+}
+
+type GlobalAchievementPercentage struct {
+	Name    string
+	Percent float64
+}
+
+type SchemaAchievement struct {
+	Name         string `json:"name"`
+	DisplayName  string `json:"displayName"`
+	Description  string `json:"description"`
+	Hidden       int    `json:"hidden"`
+	DefaultValue int    `json:"defaultvalue"`
+	Icon         string `json:"icon"`
+	IconGray     string `json:"icongray"`
+}
+
+type SchemaStat struct {
+	Name         string  `json:"name"`
+	DisplayName  string  `json:"displayName"`
+	DefaultValue float64 `json:"defaultvalue"`
+}
+
+// GameSchema lists the achievements and stats of a game, display names and
+// descriptions are in the session's language.
+type GameSchema struct {
+	GameName     string               `json:"gameName"`
+	GameVersion  string               `json:"gameVersion"`
+	Achievements []*SchemaAchievement `json:"-"`
+	Stats        []*SchemaStat        `json:"-"`
+	Fetched      time.Time            `json:"-"`
+}
+
+// AchievementOverview is an achievement of the schema along with the percentage
+// of players having it.
+type AchievementOverview struct {
+	*SchemaAchievement
+	Percent float64
+}
+
+type cachedAchievementPercentages struct {
+	percentages []*GlobalAchievementPercentage
+	fetched     time.Time
+}
+
+type achievementCache struct {
+	schemas     map[string]*GameSchema
+	percentages map[uint32]*cachedAchievementPercentages
+	mutex       sync.Mutex
+}
+
+func (session *Session) GetUserStatsForGame(sid SteamID, appID uint32) (*UserStatsForGame, error) {
+	resp, err := session.client.Get(apiGetUserStatsForGame + url.Values{
+		"key":     {session.apiKey},
+		"steamid": {sid.ToString()},
+		"appid":   {strconv.FormatUint(uint64(appID), 10)},
+	}.Encode())
+
+	type Response struct {
+		Inner *UserStatsForGame `json:"playerstats"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Inner == nil {
+		return nil, ErrNoUserStats
+	}
+
+	return response.Inner, nil
+}
+
+// GetPlayerAchievements names and descriptions are in the session's language.
+func (session *Session) GetPlayerAchievements(sid SteamID, appID uint32) (*PlayerAchievements, error) {
+	resp, err := session.client.Get(apiGetPlayerAchievements + url.Values{
+		"key":     {session.apiKey},
+		"steamid": {sid.ToString()},
+		"appid":   {strconv.FormatUint(uint64(appID), 10)},
+		"l":       {session.language},
+	}.Encode())
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// This one answers with an error message and a non-OK status code
+	// for private profiles, so decode before looking at the status.
+	type Stats struct {
+		PlayerAchievements
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+
+	type Response struct {
+		Inner Stats `json:"playerstats"`
+	}
+
+	var response Response
+	if err = session.decodeJSON(resp.Body, &response); err != nil {
+		if _, resultErr := checkEResult(resp); resultErr != nil {
+			return nil, resultErr
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+		}
+
+		return nil, err
+	}
+
+	if !response.Inner.Success {
+		if len(response.Inner.Error) != 0 {
+			return nil, errors.New(response.Inner.Error)
+		}
+
+		if _, err = checkEResult(resp); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	return &response.Inner.PlayerAchievements, nil
+}
+
+// GetGlobalAchievementPercentages is cached on the session for
+// GlobalAchievementsCacheTime.
+func (session *Session) GetGlobalAchievementPercentages(appID uint32) ([]*GlobalAchievementPercentage, error) {
+	cache := &session.achievements
+	cache.mutex.Lock()
+	cached, ok := cache.percentages[appID]
+	cache.mutex.Unlock()
+
+	if ok && time.Since(cached.fetched) < GlobalAchievementsCacheTime {
+		return cached.percentages, nil
+	}
+
+	percentages, err := session.fetchGlobalAchievementPercentages(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mutex.Lock()
+	if cache.percentages == nil {
+		cache.percentages = make(map[uint32]*cachedAchievementPercentages)
+	}
+	cache.percentages[appID] = &cachedAchievementPercentages{percentages, time.Now()}
+	cache.mutex.Unlock()
+
+	return percentages, nil
+}
+
+func (session *Session) fetchGlobalAchievementPercentages(appID uint32) ([]*GlobalAchievementPercentage, error) {
+	resp, err := session.client.Get(apiGetGlobalAchievements + url.Values{
+		"gameid": {strconv.FormatUint(uint64(appID), 10)},
+	}.Encode())
+
+	// Percent has been seen both as a number and as a string.
+	type Achievement struct {
+		Name    string      `json:"name"`
+		Percent interface{} `json:"percent"`
+	}
+
+	type Achievements struct {
+		Achievements []*Achievement `json:"achievements"`
+	}
+
+	type Response struct {
+		Inner Achievements `json:"achievementpercentages"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return nil, err
+	}
+
+	percentages := make([]*GlobalAchievementPercentage, 0, len(response.Inner.Achievements))
+	for _, achievement := range response.Inner.Achievements {
+		percentage := &GlobalAchievementPercentage{Name: achievement.Name}
+		switch percent := achievement.Percent.(type) {
+		case float64:
+			percentage.Percent = percent
+		case string:
+			percentage.Percent, _ = strconv.ParseFloat(percent, 64)
+		}
+
+		percentages = append(percentages, percentage)
+	}
+
+	return percentages, nil
+}
+
+func (session *Session) fetchGameSchema(appID uint32) (*GameSchema, error) {
+	resp, err := session.client.Get(apiGetSchemaForGame + url.Values{
+		"key":   {session.apiKey},
+		"appid": {strconv.FormatUint(uint64(appID), 10)},
+		"l":     {session.language},
+	}.Encode())
+
+	type Available struct {
+		Achievements []*SchemaAchievement `json:"achievements"`
+		Stats        []*SchemaStat        `json:"stats"`
+	}
+
+	type Game struct {
+		GameSchema
+		Available Available `json:"availableGameStats"`
+	}
+
+	type Response struct {
+		Inner Game `json:"game"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return nil, err
+	}
+
+	schema := &response.Inner.GameSchema
+	schema.Achievements = response.Inner.Available.Achievements
+	schema.Stats = response.Inner.Available.Stats
+	schema.Fetched = time.Now()
+	if schema.Achievements == nil {
+		schema.Achievements = []*SchemaAchievement{}
+	}
+	if schema.Stats == nil {
+		schema.Stats = []*SchemaStat{}
+	}
+
+	return schema, nil
+}
+
+// GetGameSchema returns the achievements and stats of @appID, it is cached on the
+// session (per language) for GameSchemaCacheTime.
+func (session *Session) GetGameSchema(appID uint32) (*GameSchema, error) {
+	key := strconv.FormatUint(uint64(appID), 10) + "/" + session.language
+
+	cache := &session.achievements
+	cache.mutex.Lock()
+	schema, ok := cache.schemas[key]
+	cache.mutex.Unlock()
+
+	if ok && time.Since(schema.Fetched) < GameSchemaCacheTime {
+		return schema, nil
+	}
+
+	schema, err := session.fetchGameSchema(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mutex.Lock()
+	if cache.schemas == nil {
+		cache.schemas = make(map[string]*GameSchema)
+	}
+	cache.schemas[key] = schema
+	cache.mutex.Unlock()
+
+	return schema, nil
+}
+
+// GetAchievementsOverview joins the schema of @appID with the global unlock
+// percentages, in the order of the schema.
+func (session *Session) GetAchievementsOverview(appID uint32) ([]*AchievementOverview, error) {
+	schema, err := session.GetGameSchema(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	percentages, err := session.GetGlobalAchievementPercentages(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	percents := make(map[string]float64, len(percentages))
+	for _, percentage := range percentages {
+		percents[percentage.Name] = percentage.Percent
+	}
+
+	overview := make([]*AchievementOverview, 0, len(schema.Achievements))
+	for _, achievement := range schema.Achievements {
+		overview = append(overview, &AchievementOverview{achievement, percents[achievement.Name]})
+	}
+
+	return overview, nil
+}
+
+// GetNumberOfCurrentPlayers returns how many players are in @appID right now.
+func (session *Session) GetNumberOfCurrentPlayers(appID uint32) (uint32, error) {
+	type Players struct {
+		PlayerCount uint32 `json:"player_count"`
+		Result      int    `json:"result"`
+	}
+
+	resp, err := session.client.Get(apiGetCurrentPlayers + url.Values{
+		"appid": {strconv.FormatUint(uint64(appID), 10)},
+	}.Encode())
+
+	players := &Players{}
+	if err = session.decodeServiceResponse(resp, err, players); err != nil {
+		return 0, err
+	}
+
+	if players.Result != 1 {
+		return 0, fmt.Errorf("unable to get player count of %d: result %d", appID, players.Result)
+	}
+
+	return players.PlayerCount, nil
+}
@@ -0,0 +1,230 @@
+// Package vcr is an http.RoundTripper recording responses to a file and
+// replaying them, so that code using the steam package can be tested offline:
+//
+//	transport, err := vcr.New("testdata/inventory.json", vcr.ModeReplay, nil)
+//	session := steam.NewSession(&http.Client{Transport: transport}, "")
+//
+// Secrets (cookies, keys, passwords, tokens) are scrubbed before recording.
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	// ModeReplay only answers from the file, requests not recorded fail.
+	ModeReplay = iota
+	// ModeRecord sends the requests and records them, see Save.
+	ModeRecord
+)
+
+const scrubbed = "SCRUBBED"
+
+var ErrNoInteraction = errors.New("vcr: no recorded interaction for request")
+
+// ScrubbedHeaders are removed from recorded requests and responses.
+var ScrubbedHeaders = []string{"Cookie", "Set-Cookie", "Authorization"}
+
+// ScrubbedParams are replaced in the query and form body of recorded requests,
+// along with the timestamps so that replayed requests match.
+var ScrubbedParams = []string{
+	"key", "access_token", "oauth_token", "webcookie", "sessionid",
+	"password", "twofactorcode", "emailauth", "p", "k",
+	"t", "donotcache",
+}
+
+type Interaction struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+}
+
+type Transport struct {
+	Mode int
+	Path string
+	// Base sends the requests when recording, http.DefaultTransport if nil.
+	Base http.RoundTripper
+	// Scrub is called on every interaction before it's recorded or matched,
+	// after the default scrubbing, may be nil.
+	Scrub func(*Interaction)
+
+	interactions []*Interaction
+	used         []bool
+	mutex        sync.Mutex
+}
+
+// New loads @path when replaying.
+func New(path string, mode int, base http.RoundTripper) (*Transport, error) {
+	transport := &Transport{
+		Mode: mode,
+		Path: path,
+		Base: base,
+	}
+
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(data, &transport.interactions); err != nil {
+			return nil, err
+		}
+		transport.used = make([]bool, len(transport.interactions))
+	}
+
+	return transport, nil
+}
+
+func scrubValues(values url.Values) {
+	for _, name := range ScrubbedParams {
+		if _, ok := values[name]; ok {
+			values.Set(name, scrubbed)
+		}
+	}
+}
+
+func (transport *Transport) scrub(interaction *Interaction) {
+	if u, err := url.Parse(interaction.URL); err == nil {
+		query := u.Query()
+		scrubValues(query)
+		u.RawQuery = query.Encode()
+		interaction.URL = u.String()
+	}
+
+	for _, name := range ScrubbedHeaders {
+		interaction.Header.Del(name)
+	}
+
+	if transport.Scrub != nil {
+		transport.Scrub(interaction)
+	}
+}
+
+// request reads (and restores) the body of @req into an interaction.
+func (transport *Transport) request(req *http.Request) (*Interaction, error) {
+	interaction := &Interaction{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: http.Header{},
+	}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		interaction.RequestBody = string(body)
+
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			if values, err := url.ParseQuery(interaction.RequestBody); err == nil {
+				scrubValues(values)
+				interaction.RequestBody = values.Encode()
+			}
+		}
+	}
+
+	transport.scrub(interaction)
+	return interaction, nil
+}
+
+func (transport *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	interaction, err := transport.request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if transport.Mode == ModeReplay {
+		return transport.replay(req, interaction)
+	}
+
+	base := transport.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	interaction.StatusCode = resp.StatusCode
+	interaction.Header = http.Header{}
+	for name, values := range resp.Header {
+		interaction.Header[name] = values
+	}
+	interaction.Body = body
+	transport.scrub(interaction)
+
+	transport.mutex.Lock()
+	transport.interactions = append(transport.interactions, interaction)
+	transport.mutex.Unlock()
+	return resp, nil
+}
+
+// replay answers with the first unused interaction matching @request, so that
+// the same request made several times gets the responses in recorded order.
+func (transport *Transport) replay(req *http.Request, request *Interaction) (*http.Response, error) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	for i, interaction := range transport.interactions {
+		if transport.used[i] || interaction.Method != request.Method ||
+			interaction.URL != request.URL || interaction.RequestBody != request.RequestBody {
+			continue
+		}
+
+		transport.used[i] = true
+		header := http.Header{}
+		for name, values := range interaction.Header {
+			header[name] = values
+		}
+
+		return &http.Response{
+			Status:        http.StatusText(interaction.StatusCode),
+			StatusCode:    interaction.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(interaction.Body)),
+			ContentLength: int64(len(interaction.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, ErrNoInteraction
+}
+
+// Save writes the recorded interactions to Path.
+func (transport *Transport) Save() error {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	data, err := json.MarshalIndent(transport.interactions, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(transport.Path, data, 0644)
+}
@@ -0,0 +1,140 @@
+package vcr_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/multicus/steam"
+	"github.com/multicus/steam/vcr"
+)
+
+const hashName = "AK-47 | Redline (Field-Tested)"
+
+func replaySession(t *testing.T) *steam.Session {
+	t.Helper()
+
+	transport, err := vcr.New("testdata/replay.json", vcr.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return steam.NewSession(&http.Client{Transport: transport}, "secret")
+}
+
+func TestReplayMarket(t *testing.T) {
+	session := replaySession(t)
+
+	overview, err := session.GetMarketItemPriceOverview(730, "", steam.CurrencyUSD, hashName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if overview.ParsedLowest != 1234 || overview.ParsedMedian != 1250 || overview.ParsedVolume != 1234 {
+		t.Errorf("overview = %d/%d/%d, want 1234/1250/1234", overview.ParsedLowest, overview.ParsedMedian, overview.ParsedVolume)
+	}
+
+	prices, err := session.GetMarketItemPriceHistory(730, hashName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(prices) != 2 || prices[0].Price != 12.3 || prices[1].Count != "35" {
+		t.Errorf("prices = %+v", prices)
+	}
+
+	// The same request recorded twice is answered in order.
+	if _, err = session.GetMarketItemPriceHistory(730, hashName); err != steam.ErrCannotLoadPrices {
+		t.Errorf("second history err = %v, want ErrCannotLoadPrices", err)
+	}
+
+	if _, err = session.GetMarketItemPriceHistory(730, hashName); !errors.Is(err, vcr.ErrNoInteraction) {
+		t.Errorf("third history err = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestReplayTradeOffer(t *testing.T) {
+	session := replaySession(t)
+
+	offer, err := session.GetTradeOffer(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if offer.ID != 42 || offer.Partner != 1234 || offer.Message != "hi" || offer.State != steam.TradeStateActive {
+		t.Errorf("offer = %+v", offer)
+	}
+
+	if err = session.DeclineTradeOffer(42); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = session.GetTradeOffer(43); !errors.Is(err, vcr.ErrNoInteraction) {
+		t.Errorf("unrecorded offer err = %v, want ErrNoInteraction", err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecordScrubs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vcr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "cassette.json")
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		if string(body) != "key=secret&tradeofferid=42" {
+			t.Errorf("body sent = %q", body)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Set-Cookie": {"steamLoginSecure=secret"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"response":{}}`)),
+			Request:    req,
+		}, nil
+	})
+
+	recorder, err := vcr.New(path, vcr.ModeRecord, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = steam.NewSession(&http.Client{Transport: recorder}, "secret").DeclineTradeOffer(42); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(data, []byte("secret")) {
+		t.Errorf("secret recorded: %s", data)
+	}
+
+	player, err := vcr.New(path, vcr.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = steam.NewSession(&http.Client{Transport: player}, "other").DeclineTradeOffer(42); err != nil {
+		t.Errorf("replay: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrOfferItemsChanged = errors.New("trade offer items no longer match the inventories")
+
+// OfferItemMismatch is an item of an offer that isn't in the inventory it should
+// be in (Ours for the items we give) as it is in the offer.
+type OfferItemMismatch struct {
+	Item   *EconItem
+	Ours   bool
+	Reason string
+}
+
+// OfferVerificationError lists what VerifyOffer found wrong.
+type OfferVerificationError struct {
+	Mismatches []*OfferItemMismatch
+}
+
+func (err *OfferVerificationError) Error() string {
+	return fmt.Sprintf("%v: %d item(s) not matching", ErrOfferItemsChanged, len(err.Mismatches))
+}
+
+func (err *OfferVerificationError) Unwrap() error {
+	return ErrOfferItemsChanged
+}
+
+type inventoryKey struct {
+	AppID     uint32
+	ContextID uint64
+}
+
+// verifyItems checks @items against the inventories of @sid, fetched once per
+// app and context.
+func (session *Session) verifyItems(sid SteamID, items []*EconItem, ours bool) ([]*OfferItemMismatch, error) {
+	inventories := map[inventoryKey]map[uint64]*InventoryItem{}
+	mismatches := []*OfferItemMismatch{}
+
+	for _, item := range items {
+		key := inventoryKey{item.AppID, item.ContextID}
+		assets, ok := inventories[key]
+		if !ok {
+			inventory, err := session.GetInventory(sid, uint64(item.AppID), item.ContextID, false)
+			if err != nil {
+				return nil, err
+			}
+
+			assets = make(map[uint64]*InventoryItem, len(inventory))
+			for i := range inventory {
+				assets[inventory[i].AssetID] = &inventory[i]
+			}
+			inventories[key] = assets
+		}
+
+		reason := ""
+		asset, ok := assets[item.AssetID]
+		switch {
+		case !ok:
+			reason = "missing"
+		case asset.ClassID != item.ClassID || asset.InstanceID != item.InstanceID:
+			reason = "class or instance changed"
+		case asset.Amount < item.Amount:
+			reason = "not enough left"
+		}
+
+		if len(reason) != 0 {
+			mismatches = append(mismatches, &OfferItemMismatch{Item: item, Ours: ours, Reason: reason})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// VerifyOffer fetches both inventories again and checks that every item of @offer
+// is still there with the same class and instance, which is what gets switched in
+// item-switch scams.  The partner's inventory has to be public, an *OfferVerificationError is returned
+// if anything doesn't match.
+func (session *Session) VerifyOffer(offer *TradeOffer) error {
+	var partner SteamID
+	partner.ParseDefaults(offer.Partner)
+
+	ours, err := session.verifyItems(session.oauth.SteamID, offer.SendItems, true)
+	if err != nil {
+		return err
+	}
+
+	theirs, err := session.verifyItems(partner, offer.RecvItems, false)
+	if err != nil {
+		return err
+	}
+
+	if mismatches := append(ours, theirs...); len(mismatches) != 0 {
+		return &OfferVerificationError{Mismatches: mismatches}
+	}
+
+	return nil
+}
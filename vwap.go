@@ -0,0 +1,118 @@
+package steam
+
+import "math"
+
+// MarketDepth is the market around Price (in cents): the buy orders at Price or
+// higher, the sell orders at Price or lower and the recent sales at Price or higher.
+type MarketDepth struct {
+	Price      uint64
+	BuyOrders  uint64
+	SellOrders uint64
+	Sold       uint64
+}
+
+// PriceVWAP is the average of @prices weighted by their volume, points without
+// volume are ignored.
+func PriceVWAP(prices []*MarketItemPrice) float64 {
+	total := 0.0
+	volume := uint64(0)
+	for _, price := range prices {
+		v := price.Volume()
+		total += price.Price * float64(v)
+		volume += v
+	}
+
+	if volume == 0 {
+		return 0
+	}
+
+	return total / float64(volume)
+}
+
+// depthAt is the quantity of the last level @within accepts, the levels being
+// cumulative and best price first.
+func depthAt(levels []*MarketOrderLevel, within func(level uint64) bool) uint64 {
+	depth := uint64(0)
+	for _, level := range levels {
+		if !within(level.Price) {
+			break
+		}
+
+		depth = level.Quantity
+	}
+
+	return depth
+}
+
+// BuyDepthAt is how many items buy orders would take at @price (in cents) or more.
+func (histogram *MarketOrderHistogram) BuyDepthAt(price uint64) uint64 {
+	return depthAt(histogram.BuyOrders, func(level uint64) bool { return level >= price })
+}
+
+// SellDepthAt is how many items are listed at @price (in cents) or less.
+func (histogram *MarketOrderHistogram) SellDepthAt(price uint64) uint64 {
+	return depthAt(histogram.SellOrders, func(level uint64) bool { return level <= price })
+}
+
+// fillVWAP walks the cumulative @levels until @quantity is filled, it returns the
+// average price (in cents) and how many could be filled.
+func fillVWAP(levels []*MarketOrderLevel, quantity uint64) (float64, uint64) {
+	total := 0.0
+	filled := uint64(0)
+	for _, level := range levels {
+		if filled >= quantity {
+			break
+		}
+
+		if level.Quantity <= filled {
+			continue
+		}
+
+		n := level.Quantity - filled
+		if n > quantity-filled {
+			n = quantity - filled
+		}
+		total += float64(level.Price) * float64(n)
+		filled += n
+	}
+
+	if filled == 0 {
+		return 0, 0
+	}
+
+	return total / float64(filled), filled
+}
+
+// BuyVWAP is the average price (in cents) buying @quantity from the sell orders
+// would cost, along with how many are listed if that's less than @quantity.
+func (histogram *MarketOrderHistogram) BuyVWAP(quantity uint64) (float64, uint64) {
+	return fillVWAP(histogram.SellOrders, quantity)
+}
+
+// SellVWAP is the average price (in cents) selling @quantity to the buy orders
+// would get, along with how many they take if that's less than @quantity.
+func (histogram *MarketOrderHistogram) SellVWAP(quantity uint64) (float64, uint64) {
+	return fillVWAP(histogram.BuyOrders, quantity)
+}
+
+// VWAP is PriceVWAP of RecentHistory, in cents.
+func (data *MarketPriceData) VWAP() float64 {
+	return PriceVWAP(data.RecentHistory) * 100
+}
+
+// DepthAt merges the histogram and the recent sales at @price (in cents).
+func (data *MarketPriceData) DepthAt(price uint64) *MarketDepth {
+	depth := &MarketDepth{Price: price}
+	if data.Histogram != nil {
+		depth.BuyOrders = data.Histogram.BuyDepthAt(price)
+		depth.SellOrders = data.Histogram.SellDepthAt(price)
+	}
+
+	for _, sale := range data.RecentHistory {
+		if uint64(math.Round(sale.Price*100)) >= price {
+			depth.Sold += sale.Volume()
+		}
+	}
+
+	return depth
+}
@@ -2,6 +2,8 @@ package steam
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -25,6 +27,50 @@ var (
 	ErrKeyNotFound       = errors.New("key not found")
 )
 
+// decodeAPIResponse closes the body of @resp after decoding it into @v, see
+// decodeAPIBody.
+func (session *Session) decodeAPIResponse(resp *http.Response, err error, v interface{}) error {
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return session.decodeAPIBody(resp, resp.Body, v)
+}
+
+// decodeAPIBody decodes @body (the body of @resp, or a reader on top of it) into
+// @v.  A failure in the X-eresult header is returned as an EResultError, and a
+// success with an empty body leaves @v untouched, as Web API methods with
+// nothing to say answer with an empty body.
+func (session *Session) decodeAPIBody(resp *http.Response, body io.Reader, v interface{}) error {
+	hasResult, err := checkEResult(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+
+	if err = session.decodeJSON(body, v); err == io.EOF && hasResult {
+		return nil
+	}
+
+	return err
+}
+
+// decodeServiceResponse closes the body of @resp after decoding its "response"
+// into @inner, which is what the I*Service interfaces return, see decodeAPIBody.
+func (session *Session) decodeServiceResponse(resp *http.Response, err error, inner interface{}) error {
+	response := struct {
+		Inner interface{} `json:"response"`
+	}{inner}
+	return session.decodeAPIResponse(resp, err, &response)
+}
+
 func (session *Session) parseKey(resp *http.Response) (string, error) {
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
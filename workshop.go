@@ -0,0 +1,138 @@
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	apiGetPublishedFileDetails = "https://api.steampowered.com/IPublishedFileService/GetDetails/v1/?"
+)
+
+var (
+	ErrCannotSubscribe = errors.New("unable to change workshop subscription")
+	ErrCannotVote      = errors.New("unable to vote on published file")
+	ErrCannotFavorite  = errors.New("unable to change published file favorite")
+)
+
+type PublishedFileTag struct {
+	Tag         string `json:"tag"`
+	DisplayName string `json:"display_name"`
+}
+
+type PublishedFileDetails struct {
+	Result        uint32              `json:"result"`
+	ID            uint64              `json:"publishedfileid,string"`
+	Creator       SteamID             `json:"creator,string"`
+	CreatorAppID  uint32              `json:"creator_appid"`
+	ConsumerAppID uint32              `json:"consumer_appid"`
+	FileName      string              `json:"filename"`
+	FileSize      uint64              `json:"file_size,string"`
+	FileURL       string              `json:"file_url"`
+	PreviewURL    string              `json:"preview_url"`
+	Title         string              `json:"title"`
+	Description   string              `json:"file_description"`
+	TimeCreated   int64               `json:"time_created"`
+	TimeUpdated   int64               `json:"time_updated"`
+	Visibility    uint32              `json:"visibility"`
+	Banned        bool                `json:"banned"`
+	Subscriptions uint32              `json:"subscriptions"`
+	Favorited     uint32              `json:"favorited"`
+	Views         uint32              `json:"views"`
+	Tags          []*PublishedFileTag `json:"tags"`
+}
+
+func (session *Session) GetPublishedFileDetails(ids []uint64) ([]*PublishedFileDetails, error) {
+	params := url.Values{
+		"key":               {session.apiKey},
+		"includetags":       {"1"},
+		"short_description": {"0"},
+		"language":          {session.language},
+	}
+	for i, id := range ids {
+		params.Set(fmt.Sprintf("publishedfileids[%d]", i), strconv.FormatUint(id, 10))
+	}
+
+	resp, err := session.client.Get(apiGetPublishedFileDetails + params.Encode())
+
+	type Details struct {
+		Details []*PublishedFileDetails `json:"publishedfiledetails"`
+	}
+
+	type Response struct {
+		Inner Details `json:"response"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Inner.Details, nil
+}
+
+func (session *Session) sharedFileRequest(action string, values url.Values, failure error) error {
+	values.Set("sessionid", session.sessionID)
+
+	resp, err := session.client.PostForm("https://steamcommunity.com/sharedfiles/"+action, values)
+
+	type Response struct {
+		Success int `json:"success"`
+	}
+
+	var response Response
+	if err = session.decodeAPIResponse(resp, err, &response); err != nil {
+		return err
+	}
+
+	if response.Success != 1 {
+		return failure
+	}
+
+	return nil
+}
+
+func (session *Session) changeWorkshopSubscription(action string, id uint64, appID uint32) error {
+	return session.sharedFileRequest(action, url.Values{
+		"id":    {strconv.FormatUint(id, 10)},
+		"appid": {strconv.FormatUint(uint64(appID), 10)},
+	}, ErrCannotSubscribe)
+}
+
+func (session *Session) SubscribeWorkshopItem(id uint64, appID uint32) error {
+	return session.changeWorkshopSubscription("subscribe", id, appID)
+}
+
+func (session *Session) UnsubscribeWorkshopItem(id uint64, appID uint32) error {
+	return session.changeWorkshopSubscription("unsubscribe", id, appID)
+}
+
+// VotePublishedFile rates the published file (guide, artwork, workshop item...)
+// up or down.
+func (session *Session) VotePublishedFile(id uint64, up bool) error {
+	action := "votedown"
+	if up {
+		action = "voteup"
+	}
+
+	return session.sharedFileRequest(action, url.Values{
+		"id": {strconv.FormatUint(id, 10)},
+	}, ErrCannotVote)
+}
+
+func (session *Session) changePublishedFileFavorite(action string, id uint64, appID uint32) error {
+	return session.sharedFileRequest(action, url.Values{
+		"id":    {strconv.FormatUint(id, 10)},
+		"appid": {strconv.FormatUint(uint64(appID), 10)},
+	}, ErrCannotFavorite)
+}
+
+func (session *Session) FavoritePublishedFile(id uint64, appID uint32) error {
+	return session.changePublishedFileFavorite("favorite", id, appID)
+}
+
+func (session *Session) UnfavoritePublishedFile(id uint64, appID uint32) error {
+	return session.changePublishedFileFavorite("unfavorite", id, appID)
+}